@@ -0,0 +1,144 @@
+// Package aipquery prints query expressions as AIP-160 filter strings, see https://google.aip.dev/160.
+//
+// GAE queries and AIP-160 filters share the precedence of AND and OR, and property:expr maps to the has
+// operator ":".
+package aipquery
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/aipquery"
+)
+
+type Printer struct{}
+
+// Print returns the filter for expr.
+func (p *Printer) Print(expr ast.Expr) (string, error) {
+	var b strings.Builder
+	if err := p.expr(&b, expr, ""); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// expr writes expr, field is non-empty inside of property:expr.
+func (p *Printer) expr(b *strings.Builder, expr ast.Expr, field string) error {
+	switch e := expr.(type) {
+	case ast.And:
+		return p.join(b, []ast.Expr(e), " AND ", field)
+	case ast.Or:
+		return p.join(b, []ast.Expr(e), " OR ", field)
+	case *ast.Not:
+		b.WriteString("NOT ")
+		return p.operand(b, e.Expr, field)
+	case *ast.OperatorExpr:
+		if e.Property == "" {
+			return fmt.Errorf("%s: empty property", pkgName)
+		}
+		if !e.Operator.Valid() {
+			return fmt.Errorf("%s: invalid operator %v", pkgName, e.Operator)
+		}
+		v, err := value(e.Value)
+		if err != nil {
+			return err
+		}
+		b.WriteString(e.Property + " " + e.Operator.String() + " " + v)
+	case *ast.ColonExpr:
+		if e.Property == "" {
+			return fmt.Errorf("%s: empty property", pkgName)
+		}
+		return p.expr(b, e.Expr, e.Property)
+	case *ast.KeywordExpr:
+		if e.Stem {
+			return fmt.Errorf("%s: stemmed keyword ~%v has no AIP-160 equivalent", pkgName, e.Value)
+		}
+		v, err := value(e.Value)
+		if err != nil {
+			return err
+		}
+		if field != "" {
+			b.WriteString(field + ":")
+		}
+		b.WriteString(v)
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func (p *Printer) join(b *strings.Builder, exprs []ast.Expr, sep string, field string) error {
+	if len(exprs) == 0 {
+		return fmt.Errorf("%s: empty%sexpression", pkgName, strings.ToLower(sep))
+	}
+	for i, v := range exprs {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		if err := p.operand(b, v, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// operand writes expr, grouping it in parentheses if compound.
+func (p *Printer) operand(b *strings.Builder, expr ast.Expr, field string) error {
+	if !isCompound(expr) {
+		return p.expr(b, expr, field)
+	}
+	b.WriteString("(")
+	if err := p.expr(b, expr, field); err != nil {
+		return err
+	}
+	b.WriteString(")")
+	return nil
+}
+
+// isCompound reports whether expr is printed as several terms joined by AND or OR.
+func isCompound(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case ast.And, ast.Or:
+		return true
+	case *ast.ColonExpr:
+		return isCompound(e.Expr)
+	default:
+		return false
+	}
+}
+
+func value(v ast.Value) (string, error) {
+	switch v := v.(type) {
+	case ast.StringValue:
+		return strconv.Quote(string(v)), nil
+	case ast.IntegerValue:
+		return strconv.FormatInt(int64(v), 10), nil
+	case ast.FloatValue:
+		return formatFloat(float64(v))
+	case ast.BoolValue:
+		return strconv.FormatBool(bool(v)), nil
+	case ast.TimeValue:
+		// timestamps are RFC 3339 strings
+		return strconv.Quote(time.Time(v).Format(time.RFC3339Nano)), nil
+	default:
+		return "", fmt.Errorf("%s: unknown value type %T", pkgName, v)
+	}
+}
+
+func formatFloat(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%s: unrepresentable float value %v", pkgName, f)
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s, nil
+}
@@ -0,0 +1,59 @@
+package aipquery
+
+import (
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter_Print(t *testing.T) {
+	cases := []struct {
+		Query    string
+		Expected string
+	}{
+		{
+			`users.type != dogs AND users.type = horses`,
+			`users.type != "dogs" AND users.type = "horses"`,
+		},
+		{
+			`NOT cat OR dogs AND horses`,
+			`(NOT "cat" OR "dogs") AND "horses"`,
+		},
+		{
+			`beverage:wine color:(red OR white) NOT country:france`,
+			`beverage:"wine" AND (color:"red" OR color:"white") AND NOT country:"france"`,
+		},
+		{
+			`create_time > 2020-01-02T03:04:05Z price <= 9.5 pages >= 2.0 available = true`,
+			`create_time > "2020-01-02T03:04:05Z" AND price <= 9.5 AND pages >= 2.0 AND available = true`,
+		},
+		{
+			`title:"say \"hi\""`,
+			`title:"say \"hi\""`,
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		s, err := (&Printer{}).Print(expr)
+		if assert.NoError(t, err, c.Query) {
+			assert.Equal(t, c.Expected, s, c.Query)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			&ast.ColonExpr{Property: "title", Expr: &ast.KeywordExpr{Value: ast.StringValue("dog"), Stem: true}},
+			ast.And{},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+			&ast.OperatorExpr{Property: "", Operator: ast.OpEq, Value: ast.IntegerValue(1)},
+		} {
+			_, err := (&Printer{}).Print(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+// Package analysis splits text into terms for matching, as search services analyze text fields and queries.
+//
+// An Analyzer is a Tokenizer followed by a chain of Filters:
+//
+//	a := analysis.New(analysis.WordTokenizer, analysis.LowerCaseFilter, analysis.PorterStemFilter)
+//	a.Analyze("Running dogs") // run, dog
+package analysis
+
+// Token is a term of text, with the byte offsets of the text it came from.
+type Token struct {
+	Term string
+
+	Start, End int
+}
+
+type Analyzer interface {
+	Analyze(s string) []Token
+}
+
+// Tokenizer splits text into tokens.
+type Tokenizer interface {
+	Tokenize(s string) []Token
+}
+
+// Filter rewrites, drops or adds tokens.
+type Filter interface {
+	Filter(tokens []Token) []Token
+}
+
+// TokenizerFunc is a function which implements Tokenizer.
+type TokenizerFunc func(s string) []Token
+
+func (fn TokenizerFunc) Tokenize(s string) []Token {
+	return fn(s)
+}
+
+// FilterFunc is a function which implements Filter.
+type FilterFunc func(tokens []Token) []Token
+
+func (fn FilterFunc) Filter(tokens []Token) []Token {
+	return fn(tokens)
+}
+
+// Chain is an Analyzer which applies Filters in order to tokens of Tokenizer.
+type Chain struct {
+	Tokenizer Tokenizer
+
+	Filters []Filter
+}
+
+// New returns an Analyzer of tokenizer followed by filters.
+func New(tokenizer Tokenizer, filters ...Filter) *Chain {
+	return &Chain{
+		Tokenizer: tokenizer,
+		Filters:   filters,
+	}
+}
+
+func (c *Chain) Analyze(s string) []Token {
+	tokens := c.Tokenizer.Tokenize(s)
+	for _, f := range c.Filters {
+		tokens = f.Filter(tokens)
+	}
+	return tokens
+}
+
+// Standard analyzes text into words without case and diacritics, and CJK text into bigrams, roughly as GAE
+// analyzes text fields.
+var Standard Analyzer = New(WordTokenizer, ASCIIFoldingFilter, LowerCaseFilter, PossessiveFilter, CJKBigramFilter)
+
+// Terms returns the terms of tokens.
+func Terms(tokens []Token) []string {
+	terms := make([]string, len(tokens))
+	for i, t := range tokens {
+		terms[i] = t.Term
+	}
+	return terms
+}
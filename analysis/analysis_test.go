@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandard(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected []Token
+	}{
+		{"", nil},
+		{"Harry Potter's", []Token{{"harry", 0, 5}, {"potter", 6, 12}}},
+		{"can't stop", []Token{{"can't", 0, 5}, {"stop", 6, 10}}},
+		{"'quoted' end.", []Token{{"quoted", 1, 7}, {"end", 9, 12}}},
+		{"Café  Straße, 2nd", []Token{{"cafe", 0, 5}, {"strasse", 7, 14}, {"2nd", 16, 19}}},
+		{"東京都", []Token{{"東京", 0, 6}, {"京都", 3, 9}}},
+		{"東京 タワー", []Token{{"東京", 0, 6}, {"タワ", 7, 13}, {"ワー", 10, 16}}},
+		{"日 本", []Token{{"日", 0, 3}, {"本", 4, 7}}},
+		{"Go言語で", []Token{{"go", 0, 2}, {"言語", 2, 8}, {"語で", 5, 11}}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, Standard.Analyze(c.Input), c.Input)
+	}
+}
+
+func TestWordTokenizer(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected []string
+	}{
+		{"pi is 3.14, not 3..14", []string{"pi", "is", "3.14", "not", "3", "14"}},
+		{"1,000,000 or 1;5", []string{"1,000,000", "or", "1;5"}},
+		{"e.g. U.S.A.", []string{"e.g", "U.S.A"}},
+		{"l’amour, a,b", []string{"l’amour", "a", "b"}},
+		{"snake_case _ __x", []string{"snake_case", "__x"}},
+		{"title:potter", []string{"title", "potter"}},
+		{"a.1 1'a", []string{"a", "1", "1", "a"}},
+		{"Go's 東京'都", []string{"Go's", "東", "京", "都"}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, Terms(WordTokenizer.Tokenize(c.Input)), c.Input)
+	}
+}
+
+func TestChain(t *testing.T) {
+	a := New(WordTokenizer, LowerCaseFilter, PorterStemFilter)
+	assert.Equal(t, []string{"run", "dog", "caress"}, Terms(a.Analyze("Running DOGS, caresses")))
+
+	drop := FilterFunc(func(tokens []Token) []Token {
+		var out []Token
+		for _, t := range tokens {
+			if t.Term != "the" {
+				out = append(out, t)
+			}
+		}
+		return out
+	})
+	a = New(WordTokenizer, LowerCaseFilter, drop)
+	assert.Equal(t, []string{"hobbit"}, Terms(a.Analyze("The Hobbit")))
+}
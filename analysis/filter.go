@@ -0,0 +1,135 @@
+package analysis
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// LowerCaseFilter lower cases terms.
+var LowerCaseFilter Filter = FilterFunc(func(tokens []Token) []Token {
+	for i := range tokens {
+		tokens[i].Term = strings.ToLower(tokens[i].Term)
+	}
+	return tokens
+})
+
+// ASCIIFoldingFilter replaces Latin letters with diacritics and ligatures by their ASCII equivalents, e.g.
+// "Café" into "Cafe" and "Straße" into "Strasse".
+var ASCIIFoldingFilter Filter = FilterFunc(func(tokens []Token) []Token {
+	for i := range tokens {
+		tokens[i].Term = foldASCII(tokens[i].Term)
+	}
+	return tokens
+})
+
+var asciiFolding = map[rune]string{}
+
+func init() {
+	for _, pair := range [][2]string{
+		{"ÀÁÂÃÄÅĀĂĄǍǺ", "A"}, {"àáâãäåāăąǎǻ", "a"},
+		{"ÇĆĈĊČ", "C"}, {"çćĉċč", "c"},
+		{"ÐĎĐ", "D"}, {"ðďđ", "d"},
+		{"ÈÉÊËĒĔĖĘĚ", "E"}, {"èéêëēĕėęě", "e"},
+		{"ĜĞĠĢ", "G"}, {"ĝğġģ", "g"},
+		{"ĤĦ", "H"}, {"ĥħ", "h"},
+		{"ÌÍÎÏĨĪĬĮİǏ", "I"}, {"ìíîïĩīĭįıǐ", "i"},
+		{"Ĵ", "J"}, {"ĵ", "j"},
+		{"Ķ", "K"}, {"ķĸ", "k"},
+		{"ĹĻĽĿŁ", "L"}, {"ĺļľŀł", "l"},
+		{"ÑŃŅŇŊ", "N"}, {"ñńņňŉŋ", "n"},
+		{"ÒÓÔÕÖØŌŎŐǑǾ", "O"}, {"òóôõöøōŏőǒǿ", "o"},
+		{"ŔŖŘ", "R"}, {"ŕŗř", "r"},
+		{"ŚŜŞŠȘ", "S"}, {"śŝşšș", "s"},
+		{"ŢŤŦȚ", "T"}, {"ţťŧț", "t"},
+		{"ÙÚÛÜŨŪŬŮŰŲǓǕǗǙǛ", "U"}, {"ùúûüũūŭůűųǔǖǘǚǜ", "u"},
+		{"Ŵ", "W"}, {"ŵ", "w"},
+		{"ÝŶŸ", "Y"}, {"ýÿŷ", "y"},
+		{"ŹŻŽ", "Z"}, {"źżž", "z"},
+		{"Æ", "AE"}, {"æ", "ae"},
+		{"Œ", "OE"}, {"œ", "oe"},
+		{"Þ", "TH"}, {"þ", "th"},
+		{"ß", "ss"},
+	} {
+		for _, r := range pair[0] {
+			asciiFolding[r] = pair[1]
+		}
+	}
+}
+
+func foldASCII(s string) string {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		_, ok := asciiFolding[r]
+		return ok
+	})
+	if i < 0 {
+		return s
+	}
+	var b strings.Builder
+	b.WriteString(s[:i])
+	for _, r := range s[i:] {
+		if v, ok := asciiFolding[r]; ok {
+			b.WriteString(v)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// PossessiveFilter removes the English possessive 's from terms, e.g. "Potter's" into "Potter", which
+// WordTokenizer keeps in words.
+var PossessiveFilter Filter = FilterFunc(func(tokens []Token) []Token {
+	for i := range tokens {
+		for _, suffix := range []string{"'s", "'S", "’s", "’S"} {
+			if strings.HasSuffix(tokens[i].Term, suffix) {
+				tokens[i].Term = strings.TrimSuffix(tokens[i].Term, suffix)
+				tokens[i].End -= len(suffix)
+				break
+			}
+		}
+	}
+	return tokens
+})
+
+// CJKBigramFilter joins adjacent single character tokens of CJK scripts into overlapping bigrams, e.g.
+// "東京都" into "東京" and "京都", as CJK text is not separated by spaces. A character without an adjacent
+// one is left as is, which a query of the single character matches.
+var CJKBigramFilter Filter = FilterFunc(func(tokens []Token) []Token {
+	var out []Token
+	for i := 0; i < len(tokens); i++ {
+		if !isCJKToken(tokens[i]) {
+			out = append(out, tokens[i])
+			continue
+		}
+		j := i + 1
+		for j < len(tokens) && isCJKToken(tokens[j]) && tokens[j-1].End == tokens[j].Start {
+			j++
+		}
+		if j == i+1 {
+			out = append(out, tokens[i])
+			continue
+		}
+		for k := i; k+1 < j; k++ {
+			out = append(out, Token{
+				Term:  tokens[k].Term + tokens[k+1].Term,
+				Start: tokens[k].Start,
+				End:   tokens[k+1].End,
+			})
+		}
+		i = j - 1
+	}
+	return out
+})
+
+func isCJKToken(t Token) bool {
+	r, n := utf8.DecodeRuneInString(t.Term)
+	return n == len(t.Term) && isCJK(r)
+}
+
+// PorterStemFilter replaces lower cased English words by their stems, see Stem.
+var PorterStemFilter Filter = FilterFunc(func(tokens []Token) []Token {
+	for i := range tokens {
+		tokens[i].Term = Stem(tokens[i].Term)
+	}
+	return tokens
+})
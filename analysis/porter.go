@@ -0,0 +1,249 @@
+package analysis
+
+// Stem returns the stem of a lower cased English word by the Porter stemming algorithm, e.g. "run" of
+// "running". Words of other than a to z, and of two or less letters are returned as is.
+//
+// See https://tartarus.org/martin/PorterStemmer/ for the algorithm.
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+	s := &stemmer{b: []byte(word), k: len(word) - 1}
+	s.step1ab()
+	if s.k > 0 {
+		s.step1c()
+		s.step2()
+		s.step3()
+		s.step4()
+		s.step5()
+	}
+	return string(s.b[:s.k+1])
+}
+
+// stemmer holds a word in b[0:k+1], j is the end of the stem set by ends.
+type stemmer struct {
+	b []byte
+
+	k, j int
+}
+
+// cons reports whether b[i] is a consonant.
+func (s *stemmer) cons(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.cons(i-1)
+	default:
+		return true
+	}
+}
+
+// m returns the number of consonant sequences in b[0:j+1], which is n of [C](VC)^n[V].
+func (s *stemmer) m() int {
+	n, i := 0, 0
+	for {
+		if i > s.j {
+			return n
+		}
+		if !s.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+	for {
+		for {
+			if i > s.j {
+				return n
+			}
+			if s.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+		for {
+			if i > s.j {
+				return n
+			}
+			if !s.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+// vowelInStem reports whether b[0:j+1] contains a vowel.
+func (s *stemmer) vowelInStem() bool {
+	for i := 0; i <= s.j; i++ {
+		if !s.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleCons reports whether b[i-1:i+1] is a double consonant.
+func (s *stemmer) doubleCons(i int) bool {
+	return i >= 1 && s.b[i] == s.b[i-1] && s.cons(i)
+}
+
+// cvc reports whether b[i-2:i+1] is consonant - vowel - consonant and the last is not w, x or y, e.g. "hop".
+func (s *stemmer) cvc(i int) bool {
+	if i < 2 || !s.cons(i) || s.cons(i-1) || !s.cons(i-2) {
+		return false
+	}
+	switch s.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends reports whether b[0:k+1] ends with suffix, and sets j to the end of the rest.
+func (s *stemmer) ends(suffix string) bool {
+	n := len(suffix)
+	if n > s.k+1 || string(s.b[s.k-n+1:s.k+1]) != suffix {
+		return false
+	}
+	s.j = s.k - n
+	return true
+}
+
+// setTo replaces b[j+1:k+1] by v.
+func (s *stemmer) setTo(v string) {
+	s.b = append(s.b[:s.j+1], v...)
+	s.k = s.j + len(v)
+}
+
+func (s *stemmer) replace(v string) {
+	if s.m() > 0 {
+		s.setTo(v)
+	}
+}
+
+// step1ab removes plurals and -ed or -ing, e.g. "caresses" to "caress", "ponies" to "poni", "meeting" to
+// "meet".
+func (s *stemmer) step1ab() {
+	if s.b[s.k] == 's' {
+		switch {
+		case s.ends("sses"):
+			s.k -= 2
+		case s.ends("ies"):
+			s.setTo("i")
+		case s.b[s.k-1] != 's':
+			s.k--
+		}
+	}
+	if s.ends("eed") {
+		if s.m() > 0 {
+			s.k--
+		}
+	} else if (s.ends("ed") || s.ends("ing")) && s.vowelInStem() {
+		s.k = s.j
+		switch {
+		case s.ends("at"):
+			s.setTo("ate")
+		case s.ends("bl"):
+			s.setTo("ble")
+		case s.ends("iz"):
+			s.setTo("ize")
+		case s.doubleCons(s.k):
+			switch s.b[s.k] {
+			case 'l', 's', 'z':
+			default:
+				s.k--
+			}
+		default:
+			s.j = s.k
+			if s.m() == 1 && s.cvc(s.k) {
+				s.setTo("e")
+			}
+		}
+	}
+}
+
+// step1c turns terminal y to i when there is another vowel in the stem.
+func (s *stemmer) step1c() {
+	if s.ends("y") && s.vowelInStem() {
+		s.b[s.k] = 'i'
+	}
+}
+
+var (
+	step2Suffixes = [][2]string{
+		{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"}, {"izer", "ize"},
+		{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"},
+		{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"},
+		{"fulness", "ful"}, {"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+		{"logi", "log"},
+	}
+
+	step3Suffixes = [][2]string{
+		{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"}, {"ful", ""},
+		{"ness", ""},
+	}
+
+	step4Suffixes = []string{
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent", "ion", "ou", "ism",
+		"ate", "iti", "ous", "ive", "ize",
+	}
+)
+
+// step2 maps double suffixes to single ones, e.g. "-ization" to "-ize".
+func (s *stemmer) step2() {
+	for _, v := range step2Suffixes {
+		if s.ends(v[0]) {
+			s.replace(v[1])
+			return
+		}
+	}
+}
+
+// step3 deals with -ic-, -full, -ness etc.
+func (s *stemmer) step3() {
+	for _, v := range step3Suffixes {
+		if s.ends(v[0]) {
+			s.replace(v[1])
+			return
+		}
+	}
+}
+
+// step4 removes -ant, -ence etc. in context <c>vcvc<v>.
+func (s *stemmer) step4() {
+	for _, v := range step4Suffixes {
+		if !s.ends(v) {
+			continue
+		}
+		if v == "ion" && (s.j < 0 || (s.b[s.j] != 's' && s.b[s.j] != 't')) {
+			continue
+		}
+		if s.m() > 1 {
+			s.k = s.j
+		}
+		return
+	}
+}
+
+// step5 removes a final -e and changes -ll to -l if m > 1.
+func (s *stemmer) step5() {
+	s.j = s.k
+	if s.b[s.k] == 'e' {
+		if a := s.m(); a > 1 || a == 1 && !s.cvc(s.k-1) {
+			s.k--
+		}
+	}
+	if s.b[s.k] == 'l' && s.doubleCons(s.k) && s.m() > 1 {
+		s.k--
+	}
+}
@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	cases := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"ties":           "ti",
+		"caress":         "caress",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"conflated":      "conflat",
+		"troubled":       "troubl",
+		"sized":          "size",
+		"hopping":        "hop",
+		"tanned":         "tan",
+		"falling":        "fall",
+		"hissing":        "hiss",
+		"fizzed":         "fizz",
+		"failing":        "fail",
+		"filing":         "file",
+		"happy":          "happi",
+		"sky":            "sky",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"rational":       "ration",
+		"valenci":        "valenc",
+		"digitizer":      "digit",
+		"vietnamization": "vietnam",
+		"triplicate":     "triplic",
+		"hopeful":        "hope",
+		"goodness":       "good",
+		"revival":        "reviv",
+		"allowance":      "allow",
+		"adjustment":     "adjust",
+		"adoption":       "adopt",
+		"probate":        "probat",
+		"rate":           "rate",
+		"controll":       "control",
+		"roll":           "roll",
+		"generalization": "gener",
+		"running":        "run",
+		"dogs":           "dog",
+		"is":             "is",
+		"café":           "café",
+		"Running":        "Running",
+	}
+	for word, expected := range cases {
+		assert.Equal(t, expected, Stem(word), word)
+	}
+}
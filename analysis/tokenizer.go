@@ -0,0 +1,120 @@
+package analysis
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// WordTokenizer splits text into words by the word boundary rules of UAX #29 for letters, digits and
+// punctuation, e.g. "can't", "3.14", "1,000" and "snake_case" are words. Other punctuation and spaces
+// separate words. A colon does not join letters, unlike UAX #29, as it separates properties in queries.
+// Each character of Han, Hiragana, Katakana and Hangul is a token by itself, see CJKBigramFilter.
+var WordTokenizer Tokenizer = TokenizerFunc(tokenizeWords)
+
+func tokenizeWords(s string) []Token {
+	var (
+		tokens []Token
+		start  = -1
+		// last is the last rune of the word from start
+		last rune
+		// word is set if the word has a letter or a digit, not only connectors such as "_"
+		word bool
+	)
+	flush := func(end int) {
+		if start >= 0 && word {
+			tokens = append(tokens, Token{Term: s[start:end], Start: start, End: end})
+		}
+		start, word = -1, false
+	}
+	for i, r := range s {
+		switch {
+		case isCJK(r):
+			flush(i)
+			end := i + utf8.RuneLen(r)
+			tokens = append(tokens, Token{Term: s[i:end], Start: i, End: end})
+		case isWordRune(r) || unicode.Is(unicode.Pc, r):
+			if start < 0 {
+				start = i
+			}
+			word = word || isWordRune(r)
+			last = r
+		case start >= 0 && isMidWord(last, r, s[i+utf8.RuneLen(r):]):
+			// the next rune continues the word
+		default:
+			flush(i)
+		}
+	}
+	flush(len(s))
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+func isCJK(r rune) bool {
+	// the prolonged sound marks and the iteration mark are of the common script
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		r == 'ー' || r == 'ｰ' || r == '々'
+}
+
+var (
+	// midLetter joins letters, but the colon
+	midLetter = &unicode.RangeTable{R16: []unicode.Range16{
+		{Lo: 0x00b7, Hi: 0x00b7, Stride: 1},
+		{Lo: 0x0387, Hi: 0x0387, Stride: 1},
+		{Lo: 0x055f, Hi: 0x055f, Stride: 1},
+		{Lo: 0x05f4, Hi: 0x05f4, Stride: 1},
+		{Lo: 0x2027, Hi: 0x2027, Stride: 1},
+		{Lo: 0xfe13, Hi: 0xfe13, Stride: 1},
+		{Lo: 0xfe55, Hi: 0xfe55, Stride: 1},
+		{Lo: 0xff1a, Hi: 0xff1a, Stride: 1},
+	}}
+
+	// midNum joins digits
+	midNum = &unicode.RangeTable{R16: []unicode.Range16{
+		{Lo: 0x002c, Hi: 0x002c, Stride: 1},
+		{Lo: 0x003b, Hi: 0x003b, Stride: 1},
+		{Lo: 0x037e, Hi: 0x037e, Stride: 1},
+		{Lo: 0x0589, Hi: 0x0589, Stride: 1},
+		{Lo: 0x060c, Hi: 0x060d, Stride: 1},
+		{Lo: 0x066c, Hi: 0x066c, Stride: 1},
+		{Lo: 0x07f8, Hi: 0x07f8, Stride: 1},
+		{Lo: 0x2044, Hi: 0x2044, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe10, Stride: 1},
+		{Lo: 0xfe14, Hi: 0xfe14, Stride: 1},
+		{Lo: 0xfe50, Hi: 0xfe50, Stride: 1},
+		{Lo: 0xfe54, Hi: 0xfe54, Stride: 1},
+		{Lo: 0xff0c, Hi: 0xff0c, Stride: 1},
+		{Lo: 0xff1b, Hi: 0xff1b, Stride: 1},
+	}}
+
+	// midNumLet joins letters and digits, with the apostrophe
+	midNumLet = &unicode.RangeTable{R16: []unicode.Range16{
+		{Lo: 0x0027, Hi: 0x0027, Stride: 1},
+		{Lo: 0x002e, Hi: 0x002e, Stride: 1},
+		{Lo: 0x2018, Hi: 0x2019, Stride: 1},
+		{Lo: 0x2024, Hi: 0x2024, Stride: 1},
+		{Lo: 0xfe52, Hi: 0xfe52, Stride: 1},
+		{Lo: 0xff07, Hi: 0xff07, Stride: 1},
+		{Lo: 0xff0e, Hi: 0xff0e, Stride: 1},
+	}}
+)
+
+// isMidWord reports whether r between last and the first rune of rest is inside of a word, e.g. the
+// apostrophe of "can't" and the period of "3.14".
+func isMidWord(last, r rune, rest string) bool {
+	next, _ := utf8.DecodeRuneInString(rest)
+	switch {
+	case isLetter(last) && isLetter(next):
+		return unicode.In(r, midLetter, midNumLet)
+	case unicode.IsDigit(last) && unicode.IsDigit(next):
+		return unicode.In(r, midNum, midNumLet)
+	default:
+		return false
+	}
+}
+
+func isLetter(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsMark(r)) && !isCJK(r)
+}
@@ -0,0 +1,122 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrUnordered is returned by CompareValues if a number is NaN, which is neither less than, equal to nor
+// greater than any number.
+var ErrUnordered = errors.New(pkgName + ": NaN is not ordered")
+
+func isNaN(v Value) bool {
+	f, ok := v.(FloatValue)
+	return ok && math.IsNaN(float64(f))
+}
+
+func isOrdered(a, b Value) bool {
+	return a != nil && b != nil && a.Kind() != KindBool && b.Kind() != KindBool
+}
+
+// CompareValues returns -1, 0 or +1 depending on whether a is less than, equal to or greater than b.
+//
+// IntegerValue and FloatValue are compared as numbers exactly, ErrUnordered is returned if either is NaN, and
+// a StringValue compared with a TimeValue is converted by AsTime. Other values must be the same kind.
+func CompareValues(a, b Value) (int, error) {
+	if a == nil || b == nil {
+		return 0, fmt.Errorf("%s: can not compare %T with %T", pkgName, a, b)
+	}
+	ak, bk := a.Kind(), b.Kind()
+	switch {
+	case ak == KindInteger && bk == KindInteger:
+		return compareInt64(int64(a.(IntegerValue)), int64(b.(IntegerValue))), nil
+	case ak.IsNumeric() && bk.IsNumeric():
+		if isNaN(a) || isNaN(b) {
+			return 0, ErrUnordered
+		}
+		switch {
+		case ak == KindInteger:
+			return compareIntFloat(int64(a.(IntegerValue)), float64(b.(FloatValue))), nil
+		case bk == KindInteger:
+			return -compareIntFloat(int64(b.(IntegerValue)), float64(a.(FloatValue))), nil
+		default:
+			return compareFloat64(float64(a.(FloatValue)), float64(b.(FloatValue))), nil
+		}
+	case ak == KindTime && (bk == KindTime || bk == KindString),
+		ak == KindString && bk == KindTime:
+		at, err := AsTime(a)
+		if err != nil {
+			return 0, err
+		}
+		bt, err := AsTime(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareTime(at, bt), nil
+	case ak == KindString && bk == KindString:
+		return strings.Compare(string(a.(StringValue)), string(b.(StringValue))), nil
+	case ak == KindBool && bk == KindBool:
+		switch av, bv := bool(a.(BoolValue)), bool(b.(BoolValue)); {
+		case av == bv:
+			return 0, nil
+		case !av:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	default:
+		return 0, fmt.Errorf("%s: can not compare %v with %v", pkgName, ak, bk)
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareIntFloat compares i with f exactly, without rounding i to a float64.
+func compareIntFloat(i int64, f float64) int {
+	switch {
+	case f >= math.MaxInt64:
+		// float64(math.MaxInt64) is 2^63
+		return -1
+	case f < math.MinInt64:
+		return 1
+	}
+	t := math.Trunc(f)
+	if c := compareInt64(i, int64(t)); c != 0 {
+		return c
+	}
+	return compareFloat64(t, f)
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
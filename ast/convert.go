@@ -0,0 +1,115 @@
+package ast
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// TimeLayouts are the layouts which AsTime accepts for a StringValue, same as time literals in a query.
+var TimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+}
+
+func AsString(v Value) (string, error) {
+	switch v := v.(type) {
+	case StringValue:
+		return string(v), nil
+	case IntegerValue:
+		return strconv.FormatInt(int64(v), 10), nil
+	case FloatValue:
+		return strconv.FormatFloat(float64(v), 'g', -1, 64), nil
+	case BoolValue:
+		return strconv.FormatBool(bool(v)), nil
+	case TimeValue:
+		return time.Time(v).Format(time.RFC3339Nano), nil
+	default:
+		return "", conversionError(v, "string")
+	}
+}
+
+// AsInt64 converts v into int64.
+// FloatValue is accepted only if it has no fractional part.
+func AsInt64(v Value) (int64, error) {
+	switch v := v.(type) {
+	case IntegerValue:
+		return int64(v), nil
+	case FloatValue:
+		f := float64(v)
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, conversionError(v, "int64")
+		}
+		return int64(f), nil
+	case StringValue:
+		i, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return 0, conversionError(v, "int64")
+		}
+		return i, nil
+	default:
+		return 0, conversionError(v, "int64")
+	}
+}
+
+func AsFloat64(v Value) (float64, error) {
+	switch v := v.(type) {
+	case FloatValue:
+		return float64(v), nil
+	case IntegerValue:
+		return float64(v), nil
+	case StringValue:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return 0, conversionError(v, "float64")
+		}
+		return f, nil
+	default:
+		return 0, conversionError(v, "float64")
+	}
+}
+
+// AsTime converts v into time.Time.
+// StringValue is parsed with TimeLayouts.
+func AsTime(v Value) (time.Time, error) {
+	switch v := v.(type) {
+	case TimeValue:
+		return time.Time(v), nil
+	case StringValue:
+		for _, layout := range TimeLayouts {
+			if t, err := time.Parse(layout, string(v)); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, conversionError(v, "time.Time")
+	default:
+		return time.Time{}, conversionError(v, "time.Time")
+	}
+}
+
+// AsBool converts v into bool.
+// StringValue is accepted only if it is "true" or "false".
+func AsBool(v Value) (bool, error) {
+	switch v := v.(type) {
+	case BoolValue:
+		return bool(v), nil
+	case StringValue:
+		switch v {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return false, conversionError(v, "bool")
+	default:
+		return false, conversionError(v, "bool")
+	}
+}
+
+func conversionError(v Value, to string) error {
+	if v == nil {
+		return fmt.Errorf("%s: can not convert nil value to %s", pkgName, to)
+	}
+	return fmt.Errorf("%s: can not convert %v value %v to %s", pkgName, v.Kind(), v.Raw(), to)
+}
@@ -76,14 +76,21 @@ func (v *ColonExpr) MarshalJSON() ([]byte, error) {
 
 type KeywordExpr struct {
 	Value Value
+
+	// Stem matches variants of the word sharing its stem, e.g. "dogs" for ~dog.
+	Stem bool
 }
 
 func (v *KeywordExpr) isExpr() {}
 
 func (v *KeywordExpr) MarshalJSON() ([]byte, error) {
+	keyword := map[string]interface{}{
+		"value": v.Value,
+	}
+	if v.Stem {
+		keyword["stem"] = true
+	}
 	return json.Marshal(map[string]interface{}{
-		"keyword": map[string]interface{}{
-			"value": v.Value,
-		},
+		"keyword": keyword,
 	})
 }
@@ -4,6 +4,30 @@ import "fmt"
 
 type Op int
 
+// ParseOp parses an operator as written in a query, "<>" is an alias of "!=".
+func ParseOp(s string) (Op, error) {
+	switch s {
+	case "=":
+		return OpEq, nil
+	case "!=", "<>":
+		return OpNeq, nil
+	case "<":
+		return OpLt, nil
+	case "<=":
+		return OpLe, nil
+	case ">":
+		return OpGt, nil
+	case ">=":
+		return OpGe, nil
+	default:
+		return 0, fmt.Errorf("%s: invalid operator %q", pkgName, s)
+	}
+}
+
+func (v Op) Valid() bool {
+	return op_begin < v && v < op_end
+}
+
 func (v Op) String() string {
 	switch v {
 	case OpEq:
@@ -19,8 +43,94 @@ func (v Op) String() string {
 	case OpGe:
 		return ">="
 	default:
-		panic(fmt.Sprintf("%s: invalid operator %v", pkgName, v))
+		return fmt.Sprintf("Op(%d)", int(v))
+	}
+}
+
+// Negate returns the operator which holds exactly when v does not, e.g. "<" to ">=".
+// Invalid operators are returned as is.
+func (v Op) Negate() Op {
+	switch v {
+	case OpEq:
+		return OpNeq
+	case OpNeq:
+		return OpEq
+	case OpLt:
+		return OpGe
+	case OpLe:
+		return OpGt
+	case OpGt:
+		return OpLe
+	case OpGe:
+		return OpLt
+	default:
+		return v
+	}
+}
+
+// Flip returns the operator to use when swapping operands, e.g. "<" to ">".
+// Invalid operators are returned as is.
+func (v Op) Flip() Op {
+	switch v {
+	case OpLt:
+		return OpGt
+	case OpLe:
+		return OpGe
+	case OpGt:
+		return OpLt
+	case OpGe:
+		return OpLe
+	default:
+		return v
+	}
+}
+
+// Compare reports whether "a op b" holds.
+// Comparisons with NaN are false but "!=", which is true.
+// It returns an error if v is invalid, or a and b can not be compared by v.
+func (v Op) Compare(a, b Value) (bool, error) {
+	if !v.Valid() {
+		return false, fmt.Errorf("%s: invalid operator %v", pkgName, v)
+	}
+	if v != OpEq && v != OpNeq && !isOrdered(a, b) {
+		return false, fmt.Errorf("%s: operator %v is not applicable to %T and %T", pkgName, v, a, b)
+	}
+	c, err := CompareValues(a, b)
+	if err == ErrUnordered {
+		return v == OpNeq, nil
+	} else if err != nil {
+		return false, err
+	}
+	switch v {
+	case OpEq:
+		return c == 0, nil
+	case OpNeq:
+		return c != 0, nil
+	case OpLt:
+		return c < 0, nil
+	case OpLe:
+		return c <= 0, nil
+	case OpGt:
+		return c > 0, nil
+	default: // OpGe
+		return c >= 0, nil
+	}
+}
+
+func (v Op) MarshalText() ([]byte, error) {
+	if !v.Valid() {
+		return nil, fmt.Errorf("%s: invalid operator %v", pkgName, v)
+	}
+	return []byte(v.String()), nil
+}
+
+func (v *Op) UnmarshalText(b []byte) error {
+	op, err := ParseOp(string(b))
+	if err != nil {
+		return err
 	}
+	*v = op
+	return nil
 }
 
 const (
@@ -0,0 +1,99 @@
+package ast
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOp(t *testing.T) {
+	for s, expected := range map[string]Op{
+		"=":  OpEq,
+		"!=": OpNeq,
+		"<>": OpNeq,
+		"<":  OpLt,
+		"<=": OpLe,
+		">":  OpGt,
+		">=": OpGe,
+	} {
+		op, err := ParseOp(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, expected, op, s)
+		}
+	}
+	_, err := ParseOp("==")
+	assert.Error(t, err)
+}
+
+func TestOp(t *testing.T) {
+	ops := []Op{OpEq, OpNeq, OpLt, OpLe, OpGt, OpGe}
+	t.Run("Valid", func(t *testing.T) {
+		for _, op := range ops {
+			assert.True(t, op.Valid(), op.String())
+		}
+		assert.False(t, op_begin.Valid())
+		assert.False(t, op_end.Valid())
+		assert.Equal(t, "Op(42)", Op(42).String())
+	})
+	t.Run("Negate", func(t *testing.T) {
+		assert.Equal(t, OpGe, OpLt.Negate())
+		for _, op := range ops {
+			assert.Equal(t, op, op.Negate().Negate(), op.String())
+		}
+	})
+	t.Run("Flip", func(t *testing.T) {
+		assert.Equal(t, OpGt, OpLt.Flip())
+		assert.Equal(t, OpNeq, OpNeq.Flip())
+		for _, op := range ops {
+			assert.Equal(t, op, op.Flip().Flip(), op.String())
+		}
+	})
+	t.Run("Text", func(t *testing.T) {
+		b, err := json.Marshal(map[string]Op{"op": OpLe})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, `{"op":"\u003c="}`, string(b))
+		var v map[string]Op
+		if assert.NoError(t, json.Unmarshal([]byte(`{"op":"<>"}`), &v)) {
+			assert.Equal(t, OpNeq, v["op"])
+		}
+		_, err = Op(0).MarshalText()
+		assert.Error(t, err)
+	})
+	t.Run("Compare", func(t *testing.T) {
+		t1 := TimeValue(time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC))
+		t2 := TimeValue(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+		cases := []struct {
+			A, B     Value
+			Expected map[Op]bool
+		}{
+			{IntegerValue(1), IntegerValue(2), map[Op]bool{OpEq: false, OpNeq: true, OpLt: true, OpLe: true, OpGt: false, OpGe: false}},
+			{IntegerValue(2), FloatValue(2), map[Op]bool{OpEq: true, OpNeq: false, OpLt: false, OpLe: true, OpGt: false, OpGe: true}},
+			{FloatValue(2.5), IntegerValue(2), map[Op]bool{OpEq: false, OpNeq: true, OpLt: false, OpLe: false, OpGt: true, OpGe: true}},
+			{StringValue("a"), StringValue("b"), map[Op]bool{OpEq: false, OpNeq: true, OpLt: true, OpLe: true, OpGt: false, OpGe: false}},
+			{t2, t1, map[Op]bool{OpEq: false, OpNeq: true, OpLt: false, OpLe: false, OpGt: true, OpGe: true}},
+			{BoolValue(true), BoolValue(true), map[Op]bool{OpEq: true, OpNeq: false}},
+			{FloatValue(math.NaN()), IntegerValue(1), map[Op]bool{OpEq: false, OpNeq: true, OpLt: false, OpLe: false, OpGt: false, OpGe: false}},
+			{FloatValue(2), FloatValue(math.NaN()), map[Op]bool{OpEq: false, OpNeq: true, OpLt: false, OpLe: false, OpGt: false, OpGe: false}},
+			{FloatValue(math.NaN()), FloatValue(math.NaN()), map[Op]bool{OpEq: false, OpNeq: true, OpLt: false, OpLe: false, OpGt: false, OpGe: false}},
+		}
+		for _, c := range cases {
+			for op, expected := range c.Expected {
+				actual, err := op.Compare(c.A, c.B)
+				if assert.NoError(t, err, "%v %v %v", c.A, op, c.B) {
+					assert.Equal(t, expected, actual, "%v %v %v", c.A, op, c.B)
+				}
+			}
+		}
+		_, err := OpLt.Compare(BoolValue(false), BoolValue(true))
+		assert.Error(t, err)
+		_, err = OpEq.Compare(StringValue("1"), IntegerValue(1))
+		assert.Error(t, err)
+		_, err = Op(0).Compare(IntegerValue(1), IntegerValue(1))
+		assert.Error(t, err)
+	})
+}
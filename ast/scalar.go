@@ -0,0 +1,112 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Scalar is an expression computing a value of a document, as in sort and field expressions.
+type Scalar interface {
+	isScalar()
+}
+
+type Literal struct {
+	Value Value
+}
+
+func (v *Literal) isScalar() {}
+
+func (v *Literal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"literal": v.Value,
+	})
+}
+
+// FieldRef refers to a field of a document, or to _score and _rank.
+type FieldRef struct {
+	Name string
+}
+
+func (v *FieldRef) isScalar() {}
+
+func (v *FieldRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"field": v.Name,
+	})
+}
+
+type BinaryExpr struct {
+	Op ArithOp
+
+	X Scalar
+
+	Y Scalar
+}
+
+func (v *BinaryExpr) isScalar() {}
+
+func (v *BinaryExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		v.Op.String(): []Scalar{v.X, v.Y},
+	})
+}
+
+// Neg is the unary minus.
+type Neg struct {
+	X Scalar
+}
+
+func (v *Neg) isScalar() {}
+
+func (v *Neg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"neg": v.X,
+	})
+}
+
+type Call struct {
+	Func string
+
+	Args []Scalar
+}
+
+func (v *Call) isScalar() {}
+
+func (v *Call) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"call": map[string]interface{}{
+			"func": v.Func,
+			"args": v.Args,
+		},
+	})
+}
+
+type ArithOp int
+
+const (
+	arithOp_begin ArithOp = iota
+	ArithAdd
+	ArithSub
+	ArithMul
+	ArithDiv
+	arithOp_end
+)
+
+func (v ArithOp) Valid() bool {
+	return arithOp_begin < v && v < arithOp_end
+}
+
+func (v ArithOp) String() string {
+	switch v {
+	case ArithAdd:
+		return "+"
+	case ArithSub:
+		return "-"
+	case ArithMul:
+		return "*"
+	case ArithDiv:
+		return "/"
+	default:
+		return fmt.Sprintf("ArithOp(%d)", int(v))
+	}
+}
@@ -2,19 +2,58 @@ package ast
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
 type Value interface {
 	isValue()
 
+	Kind() Kind
+
 	Raw() interface{}
 }
 
+type Kind int
+
+func (v Kind) String() string {
+	switch v {
+	case KindTime:
+		return "time"
+	case KindFloat:
+		return "float"
+	case KindInteger:
+		return "integer"
+	case KindBool:
+		return "bool"
+	case KindString:
+		return "string"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(v))
+	}
+}
+
+// IsNumeric reports whether v is KindInteger or KindFloat.
+func (v Kind) IsNumeric() bool {
+	return v == KindInteger || v == KindFloat
+}
+
+const (
+	KindTime Kind = iota + 1
+	KindFloat
+	KindInteger
+	KindBool
+	KindString
+)
+
 type TimeValue time.Time
 
 func (v TimeValue) isValue() {}
 
+func (v TimeValue) Kind() Kind {
+	return KindTime
+}
+
 func (v TimeValue) Raw() interface{} {
 	return time.Time(v)
 }
@@ -29,6 +68,10 @@ type FloatValue float64
 
 func (v FloatValue) isValue() {}
 
+func (v FloatValue) Kind() Kind {
+	return KindFloat
+}
+
 func (v FloatValue) Raw() interface{} {
 	return float64(v)
 }
@@ -43,6 +86,10 @@ type IntegerValue int64
 
 func (v IntegerValue) isValue() {}
 
+func (v IntegerValue) Kind() Kind {
+	return KindInteger
+}
+
 func (v IntegerValue) Raw() interface{} {
 	return int64(v)
 }
@@ -57,6 +104,10 @@ type BoolValue bool
 
 func (v BoolValue) isValue() {}
 
+func (v BoolValue) Kind() Kind {
+	return KindBool
+}
+
 func (v BoolValue) Raw() interface{} {
 	return bool(v)
 }
@@ -71,6 +122,10 @@ type StringValue string
 
 func (v StringValue) isValue() {}
 
+func (v StringValue) Kind() Kind {
+	return KindString
+}
+
 func (v StringValue) Raw() interface{} {
 	return string(v)
 }
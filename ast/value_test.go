@@ -0,0 +1,126 @@
+package ast
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKind(t *testing.T) {
+	assert.Equal(t, KindTime, TimeValue{}.Kind())
+	assert.Equal(t, KindFloat, FloatValue(0).Kind())
+	assert.Equal(t, KindInteger, IntegerValue(0).Kind())
+	assert.Equal(t, KindBool, BoolValue(false).Kind())
+	assert.Equal(t, KindString, StringValue("").Kind())
+	assert.Equal(t, "integer", KindInteger.String())
+	assert.True(t, KindFloat.IsNumeric())
+	assert.False(t, KindString.IsNumeric())
+}
+
+func TestCompareValues(t *testing.T) {
+	date := TimeValue(time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC))
+	cases := []struct {
+		A, B     Value
+		Expected int
+	}{
+		{IntegerValue(1), IntegerValue(2), -1},
+		{IntegerValue(2), FloatValue(1.5), 1},
+		{FloatValue(3), IntegerValue(3), 0},
+		{IntegerValue(1<<53 + 1), FloatValue(1 << 53), 1},
+		{FloatValue(1 << 53), IntegerValue(1<<53 + 1), -1},
+		{IntegerValue(-3), FloatValue(-2.5), -1},
+		{IntegerValue(-2), FloatValue(-2.5), 1},
+		{IntegerValue(math.MaxInt64), FloatValue(math.MaxInt64), -1},
+		{IntegerValue(math.MinInt64), FloatValue(math.MinInt64), 0},
+		{IntegerValue(math.MinInt64), FloatValue(math.Inf(-1)), 1},
+		{StringValue("b"), StringValue("a"), 1},
+		{BoolValue(false), BoolValue(true), -1},
+		{date, StringValue("1965-01-01"), 0},
+		{StringValue("1964-12-31T23:59:59Z"), date, -1},
+		{date, TimeValue(time.Date(1965, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))), 0},
+	}
+	for _, c := range cases {
+		actual, err := CompareValues(c.A, c.B)
+		if assert.NoError(t, err, "%v %v", c.A, c.B) {
+			assert.Equal(t, c.Expected, actual, "%v %v", c.A, c.B)
+		}
+	}
+	for _, c := range [][2]Value{
+		{StringValue("1"), IntegerValue(1)},
+		{BoolValue(true), IntegerValue(1)},
+		{date, StringValue("yesterday")},
+		{nil, IntegerValue(1)},
+		{FloatValue(math.NaN()), IntegerValue(1)},
+		{FloatValue(1), FloatValue(math.NaN())},
+	} {
+		_, err := CompareValues(c[0], c[1])
+		assert.Error(t, err, "%v %v", c[0], c[1])
+	}
+	_, err := CompareValues(IntegerValue(1), FloatValue(math.NaN()))
+	assert.Equal(t, ErrUnordered, err)
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("AsString", func(t *testing.T) {
+		for v, expected := range map[Value]string{
+			StringValue("x"): "x",
+			IntegerValue(-3): "-3",
+			FloatValue(0.25): "0.25",
+			BoolValue(true):  "true",
+			TimeValue(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)): "2020-01-02T03:04:05Z",
+		} {
+			s, err := AsString(v)
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected, s)
+			}
+		}
+	})
+	t.Run("AsInt64", func(t *testing.T) {
+		for v, expected := range map[Value]int64{
+			IntegerValue(7):   7,
+			FloatValue(8):     8,
+			StringValue("-9"): -9,
+		} {
+			i, err := AsInt64(v)
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected, i)
+			}
+		}
+		for _, v := range []Value{FloatValue(1.5), StringValue("x"), BoolValue(true), nil} {
+			_, err := AsInt64(v)
+			assert.Error(t, err, "%v", v)
+		}
+	})
+	t.Run("AsFloat64", func(t *testing.T) {
+		for v, expected := range map[Value]float64{
+			IntegerValue(7):    7,
+			FloatValue(8.5):    8.5,
+			StringValue("0.5"): 0.5,
+		} {
+			f, err := AsFloat64(v)
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected, f)
+			}
+		}
+		_, err := AsFloat64(TimeValue{})
+		assert.Error(t, err)
+	})
+	t.Run("AsTime", func(t *testing.T) {
+		v, err := AsTime(StringValue("2020-01-02"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), v)
+		}
+		_, err = AsTime(IntegerValue(0))
+		assert.Error(t, err)
+	})
+	t.Run("AsBool", func(t *testing.T) {
+		v, err := AsBool(StringValue("false"))
+		if assert.NoError(t, err) {
+			assert.False(t, v)
+		}
+		_, err = AsBool(StringValue("yes"))
+		assert.Error(t, err)
+	})
+}
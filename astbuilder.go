@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kamichidu/go-gae-search-query/ast"
@@ -15,9 +16,22 @@ type astBuilder struct {
 
 	Debug bool
 
+	logger *log.Logger
+
+	limits limits
+
+	location *time.Location
+
 	state list.List
 
 	stateStack list.List
+
+	depth int
+
+	nodes int
+
+	// partial parses the beginning of a query, see parsePartial
+	partial bool
 }
 
 func (a *astBuilder) pushState(v interface{}) {
@@ -45,9 +59,35 @@ func (a *astBuilder) log(format string, args ...interface{}) {
 	if !a.Debug {
 		return
 	}
+	if a.logger != nil {
+		a.logger.Printf("trace: "+format, args...)
+		return
+	}
 	log.Printf("trace: "+format, args...)
 }
 
+func (a *astBuilder) enter(pos uint32) {
+	a.log("enter")
+
+	a.depth++
+	if max := a.limits.MaxDepth; max > 0 && a.depth > max {
+		panic(&LimitError{Limit: LimitDepth, Max: max, Offset: int(pos)})
+	}
+}
+
+func (a *astBuilder) leave() {
+	a.log("leave")
+
+	a.depth--
+}
+
+func (a *astBuilder) addNode(pos uint32) {
+	a.nodes++
+	if max := a.limits.MaxNodes; max > 0 && a.nodes > max {
+		panic(&LimitError{Limit: LimitNodes, Max: max, Offset: int(pos)})
+	}
+}
+
 func (a *astBuilder) finalize() {
 	a.log("finalize")
 
@@ -60,13 +100,14 @@ func (a *astBuilder) finalize() {
 	a.Expr = a.state.Remove(a.state.Front()).(ast.Expr)
 }
 
-func (a *astBuilder) reduceAnd() {
+func (a *astBuilder) reduceAnd(pos uint32) {
 	a.log("reduceAnd")
 
 	// remaining an expr, do nothing
 	if n := a.state.Len(); n == 1 {
 		return
 	}
+	a.addNode(pos)
 	var and ast.And
 	for ele := a.state.Back(); ele != nil; ele = ele.Prev() {
 		and = append(and, ele.Value.(ast.Expr))
@@ -75,9 +116,11 @@ func (a *astBuilder) reduceAnd() {
 	a.state.PushFront(and)
 }
 
-func (a *astBuilder) pushNewState() {
+func (a *astBuilder) pushNewState(pos uint32) {
 	a.log("pushNewState")
 
+	a.enter(pos)
+
 	a.stateStack.PushFront(a.state)
 	a.state.Init()
 }
@@ -95,11 +138,14 @@ func (a *astBuilder) popNewState() {
 	a.state.Init()
 	a.state.PushFrontList(&prevState)
 	a.state.PushFront(expr)
+	a.leave()
 }
 
-func (a *astBuilder) pushOr() {
+func (a *astBuilder) pushOr(pos uint32) {
 	a.log("pushOr")
 
+	a.addNode(pos)
+
 	expr2_ := a.popState()
 	expr1_ := a.popState()
 
@@ -111,12 +157,25 @@ func (a *astBuilder) pushOr() {
 	if !ok {
 		panic(fmt.Sprintf("%s: invalid state: expr2 = %T", pkgName, expr2_))
 	}
+	if max := a.limits.MaxOrTerms; max > 0 {
+		// "a OR b OR c" is built as ((a OR b) OR c), count the terms along the left spine
+		n := 2
+		for or, ok := expr1.(ast.Or); ok && len(or) == 2; or, ok = or[0].(ast.Or) {
+			n++
+		}
+		if n > max {
+			panic(&LimitError{Limit: LimitOrTerms, Max: max, Offset: int(pos)})
+		}
+	}
 	a.pushState(ast.Or{expr1, expr2})
 }
 
-func (a *astBuilder) pushNot() {
+func (a *astBuilder) pushNot(pos uint32) {
 	a.log("pushNot")
 
+	a.leave()
+	a.addNode(pos)
+
 	expr_ := a.popState()
 
 	expr, ok := expr_.(ast.Expr)
@@ -140,12 +199,16 @@ func (a *astBuilder) pushOperator(v ast.Op) {
 	a.pushState(v)
 }
 
-func (a *astBuilder) pushTimeValue(layout, s string) {
+func (a *astBuilder) pushTimeValue(pos int, layout, s string) {
 	a.log("pushTimeValue %q %q", layout, s)
 
-	v, err := time.Parse(layout, s)
+	loc := a.location
+	if loc == nil {
+		loc = time.UTC
+	}
+	v, err := time.ParseInLocation(layout, s, loc)
 	if err != nil {
-		panic(err)
+		panic(&ValueError{Value: s, Offset: pos, Err: err})
 	}
 	a.pushState(ast.TimeValue(v))
 }
@@ -156,22 +219,35 @@ func (a *astBuilder) pushStringValue(s string) {
 	a.pushState(ast.StringValue(s))
 }
 
-func (a *astBuilder) pushIntegerValue(s string) {
+func (a *astBuilder) pushQuotedStringValue(s string) {
+	a.log("pushQuotedStringValue %q", s)
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	a.pushState(ast.StringValue(b.String()))
+}
+
+func (a *astBuilder) pushIntegerValue(pos int, s string) {
 	a.log("pushIntegerValue %q", s)
 
 	v, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
-		panic(err)
+		panic(&ValueError{Value: s, Offset: pos, Err: err.(*strconv.NumError).Err})
 	}
 	a.pushState(ast.IntegerValue(v))
 }
 
-func (a *astBuilder) pushFloatValue(s string) {
+func (a *astBuilder) pushFloatValue(pos int, s string) {
 	a.log("pushFloatValue %q", s)
 
 	v, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		panic(err)
+		panic(&ValueError{Value: s, Offset: pos, Err: err.(*strconv.NumError).Err})
 	}
 	a.pushState(ast.FloatValue(v))
 }
@@ -182,9 +258,11 @@ func (a *astBuilder) pushBoolValue(v bool) {
 	a.pushState(ast.BoolValue(v))
 }
 
-func (a *astBuilder) pushOperatorExpr() {
+func (a *astBuilder) pushOperatorExpr(pos uint32) {
 	a.log("pushOperatorExpr")
 
+	a.addNode(pos)
+
 	var expr ast.OperatorExpr
 	expr.Value = a.popState().(ast.Value)
 	expr.Operator = a.popState().(ast.Op)
@@ -192,19 +270,35 @@ func (a *astBuilder) pushOperatorExpr() {
 	a.pushState(&expr)
 }
 
-func (a *astBuilder) pushColonExpr() {
+func (a *astBuilder) pushColonExpr(pos uint32) {
 	a.log("pushColonExpr")
 
+	a.leave()
+	a.addNode(pos)
+
 	var expr ast.ColonExpr
 	expr.Expr = a.popState().(ast.Expr)
 	expr.Property = a.popState().(string)
 	a.pushState(&expr)
 }
 
-func (a *astBuilder) pushKeywordExpr() {
+func (a *astBuilder) pushKeywordExpr(pos uint32) {
 	a.log("pushKeywordExpr")
 
+	a.addNode(pos)
+
+	var expr ast.KeywordExpr
+	expr.Value = a.popState().(ast.Value)
+	a.pushState(&expr)
+}
+
+func (a *astBuilder) pushStemKeywordExpr(pos uint32) {
+	a.log("pushStemKeywordExpr")
+
+	a.addNode(pos)
+
 	var expr ast.KeywordExpr
 	expr.Value = a.popState().(ast.Value)
+	expr.Stem = true
 	a.pushState(&expr)
 }
@@ -0,0 +1,207 @@
+// Package blevequery translates query expressions into Bleve queries, see https://blevesearch.com.
+//
+// Comparisons of numbers and times become numeric and date range queries, and text searches become match
+// or match phrase queries, which analyze the text with the analyzer of the field.
+package blevequery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/blevequery"
+)
+
+type Translator struct {
+	// DefaultField is the field searched by keywords outside of property:expr.
+	// The index's default field, "_all" unless configured, is used if empty.
+	DefaultField string
+
+	// StemAnalyzer is the analyzer of stemmed keywords such as ~dog, e.g. "en", so that they match fields
+	// indexed by the stemming analyzer. Stemmed keywords are an error if empty.
+	StemAnalyzer string
+}
+
+// Translate returns the query for expr.
+// String comparisons are of exact terms, so that fields compared with = should be indexed by the keyword
+// analyzer.
+func (t *Translator) Translate(expr ast.Expr) (query.Query, error) {
+	return t.expr(expr, "")
+}
+
+// expr translates expr, field is non-empty inside of property:expr.
+func (t *Translator) expr(expr ast.Expr, field string) (query.Query, error) {
+	switch e := expr.(type) {
+	case ast.And:
+		if len(e) == 0 {
+			return nil, fmt.Errorf("%s: empty and expression", pkgName)
+		}
+		var must, mustNot []query.Query
+		for _, v := range e {
+			if not, ok := v.(*ast.Not); ok {
+				q, err := t.expr(not.Expr, field)
+				if err != nil {
+					return nil, err
+				}
+				mustNot = append(mustNot, q)
+				continue
+			}
+			q, err := t.expr(v, field)
+			if err != nil {
+				return nil, err
+			}
+			must = append(must, q)
+		}
+		if len(mustNot) == 0 {
+			return query.NewConjunctionQuery(must), nil
+		}
+		return query.NewBooleanQuery(must, nil, mustNot), nil
+	case ast.Or:
+		if len(e) == 0 {
+			return nil, fmt.Errorf("%s: empty or expression", pkgName)
+		}
+		var should []query.Query
+		for _, v := range e {
+			q, err := t.expr(v, field)
+			if err != nil {
+				return nil, err
+			}
+			should = append(should, q)
+		}
+		return query.NewDisjunctionQuery(should), nil
+	case *ast.Not:
+		q, err := t.expr(e.Expr, field)
+		if err != nil {
+			return nil, err
+		}
+		// a boolean query of must not clauses only matches all other documents
+		return query.NewBooleanQuery(nil, nil, []query.Query{q}), nil
+	case *ast.OperatorExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		if e.Operator == ast.OpNeq {
+			q, err := compare(e.Property, ast.OpEq, e.Value)
+			if err != nil {
+				return nil, err
+			}
+			return query.NewBooleanQuery(nil, nil, []query.Query{q}), nil
+		}
+		return compare(e.Property, e.Operator, e.Value)
+	case *ast.ColonExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		return t.expr(e.Expr, e.Property)
+	case *ast.KeywordExpr:
+		if field == "" {
+			field = t.DefaultField
+		}
+		s, ok := e.Value.(ast.StringValue)
+		if !ok {
+			if field == "" {
+				return nil, fmt.Errorf("%s: keyword %v of %v requires a field", pkgName, e.Value, e.Value.Kind())
+			}
+			return compare(field, ast.OpEq, e.Value)
+		}
+		if e.Stem && t.StemAnalyzer == "" {
+			return nil, fmt.Errorf("%s: stemmed keyword ~%q needs StemAnalyzer", pkgName, s)
+		}
+		if strings.IndexFunc(string(s), unicode.IsSpace) >= 0 {
+			q := query.NewMatchPhraseQuery(string(s))
+			q.SetField(field)
+			if e.Stem {
+				q.Analyzer = t.StemAnalyzer
+			}
+			return q, nil
+		}
+		q := query.NewMatchQuery(string(s))
+		q.SetField(field)
+		if e.Stem {
+			q.Analyzer = t.StemAnalyzer
+		}
+		return q, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+}
+
+// compare returns the query of field op v, op is not OpNeq.
+func compare(field string, op ast.Op, v ast.Value) (query.Query, error) {
+	var (
+		min, max       interface{}
+		minInc, maxInc = true, true
+	)
+	switch op {
+	case ast.OpEq:
+		min, max = v, v
+	case ast.OpLt:
+		max, maxInc = v, false
+	case ast.OpLe:
+		max = v
+	case ast.OpGt:
+		min, minInc = v, false
+	case ast.OpGe:
+		min = v
+	default:
+		return nil, fmt.Errorf("%s: invalid operator %v", pkgName, op)
+	}
+	switch v := v.(type) {
+	case ast.IntegerValue, ast.FloatValue:
+		f, _ := ast.AsFloat64(v)
+		var minF, maxF *float64
+		if min != nil {
+			minF = &f
+		}
+		if max != nil {
+			maxF = &f
+		}
+		q := query.NewNumericRangeInclusiveQuery(minF, maxF, &minInc, &maxInc)
+		q.SetField(field)
+		return q, nil
+	case ast.TimeValue:
+		var start, end time.Time
+		if min != nil {
+			start = time.Time(v)
+		}
+		if max != nil {
+			end = time.Time(v)
+		}
+		q := query.NewDateRangeInclusiveQuery(start, end, &minInc, &maxInc)
+		q.SetField(field)
+		return q, nil
+	case ast.StringValue:
+		if op == ast.OpEq {
+			q := query.NewTermQuery(string(v))
+			q.SetField(field)
+			return q, nil
+		}
+		var minS, maxS string
+		if min != nil {
+			minS = string(v)
+		}
+		if max != nil {
+			maxS = string(v)
+		}
+		q := query.NewTermRangeInclusiveQuery(minS, maxS, &minInc, &maxInc)
+		q.SetField(field)
+		return q, nil
+	case ast.BoolValue:
+		if op != ast.OpEq {
+			return nil, fmt.Errorf("%s: property %q: %v has no order", pkgName, field, v.Kind())
+		}
+		q := query.NewBoolFieldQuery(bool(v))
+		q.SetField(field)
+		return q, nil
+	case nil:
+		return nil, fmt.Errorf("%s: property %q: nil value", pkgName, field)
+	default:
+		return nil, fmt.Errorf("%s: property %q: unknown value type %T", pkgName, field, v)
+	}
+}
@@ -0,0 +1,100 @@
+package blevequery
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	index, err := bleve.NewMemOnly(bleve.NewIndexMapping())
+	require.NoError(t, err)
+	defer index.Close()
+
+	docs := map[string]map[string]interface{}{
+		"1": {
+			"title":     "Harry Potter and the Philosopher's Stone",
+			"type":      "novel",
+			"pages":     223,
+			"published": time.Date(1997, 6, 26, 0, 0, 0, 0, time.UTC),
+			"available": true,
+		},
+		"2": {
+			"title":     "The Hobbit",
+			"type":      "novel",
+			"pages":     310,
+			"published": time.Date(1937, 9, 21, 0, 0, 0, 0, time.UTC),
+			"available": false,
+		},
+		"3": {
+			"title":     "Field of the Potter",
+			"type":      "mystery",
+			"pages":     150.5,
+			"published": time.Date(1978, 1, 1, 0, 0, 0, 0, time.UTC),
+			"available": true,
+		},
+	}
+	for id, doc := range docs {
+		require.NoError(t, index.Index(id, doc))
+	}
+
+	tr := &Translator{DefaultField: "title", StemAnalyzer: "en"}
+	cases := []struct {
+		Query    string
+		Expected []string
+	}{
+		{`potter`, []string{"1", "3"}},
+		{`"harry potter"`, []string{"1"}},
+		{`title:hobbit OR type = mystery`, []string{"2", "3"}},
+		{`potter NOT type:mystery`, []string{"1"}},
+		{`NOT potter`, []string{"2"}},
+		{`type != novel`, []string{"3"}},
+		{`pages < 223`, []string{"3"}},
+		{`pages <= 223`, []string{"1", "3"}},
+		{`pages > 150.5 pages:310`, []string{"2"}},
+		{`published >= 1978-01-01 published < 1997-06-26`, []string{"3"}},
+		{`available = false`, []string{"2"}},
+		{`hobbits`, nil},
+		{`~hobbits`, []string{"2"}},
+		{`~"the hobbits"`, []string{"2"}},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		q, err := tr.Translate(expr)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		res, err := index.Search(bleve.NewSearchRequest(q))
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		var ids []string
+		for _, hit := range res.Hits {
+			ids = append(ids, hit.ID)
+		}
+		sort.Strings(ids)
+		assert.Equal(t, c.Expected, ids, c.Query)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			ast.And{},
+			&ast.KeywordExpr{Value: ast.IntegerValue(1)},
+			&ast.KeywordExpr{Value: ast.StringValue("hobbits"), Stem: true},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+			&ast.OperatorExpr{Property: "a", Operator: ast.OpLt, Value: ast.BoolValue(true)},
+		} {
+			_, err := (&Translator{}).Translate(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
@@ -0,0 +1,9 @@
+module github.com/kamichidu/go-gae-search-query/blevequery
+
+go 1.14
+
+require (
+	github.com/blevesearch/bleve/v2 v2.0.1
+	github.com/kamichidu/go-gae-search-query v0.0.0-20261019054831-ab1e8e5f1e68
+	github.com/stretchr/testify v1.6.1
+)
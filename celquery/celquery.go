@@ -0,0 +1,184 @@
+// Package celquery prints query expressions as CEL source text, see https://github.com/google/cel-spec.
+//
+// Comparisons become CEL comparisons of the properties, e.g.
+//
+//	users.type != "dogs" && users.type == "horses"
+//
+// and time values become timestamp("...") literals.
+package celquery
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/celquery"
+)
+
+type Printer struct {
+	// KeywordFields are the string fields searched by keywords outside of property:expr.
+	// A keyword is an error if KeywordFields is empty, since CEL has no global search.
+	KeywordFields []string
+}
+
+// Print returns CEL source text for expr.
+// Text searches of keywords become contains calls, e.g. title:potter becomes title.contains("potter").
+func (p *Printer) Print(expr ast.Expr) (string, error) {
+	var b strings.Builder
+	if err := p.expr(&b, expr, ""); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// expr writes expr, field is non-empty inside of property:expr.
+func (p *Printer) expr(b *strings.Builder, expr ast.Expr, field string) error {
+	switch e := expr.(type) {
+	case ast.And:
+		return p.join(b, []ast.Expr(e), " && ", field)
+	case ast.Or:
+		return p.join(b, []ast.Expr(e), " || ", field)
+	case *ast.Not:
+		b.WriteString("!(")
+		if err := p.expr(b, e.Expr, field); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case *ast.OperatorExpr:
+		if e.Property == "" {
+			return fmt.Errorf("%s: empty property", pkgName)
+		}
+		op, err := operator(e.Operator)
+		if err != nil {
+			return err
+		}
+		v, err := value(e.Value)
+		if err != nil {
+			return err
+		}
+		b.WriteString(e.Property + " " + op + " " + v)
+	case *ast.ColonExpr:
+		if e.Property == "" {
+			return fmt.Errorf("%s: empty property", pkgName)
+		}
+		return p.expr(b, e.Expr, e.Property)
+	case *ast.KeywordExpr:
+		if e.Stem {
+			return fmt.Errorf("%s: stemmed keyword ~%v has no CEL equivalent", pkgName, e.Value)
+		}
+		if field != "" {
+			return p.keyword(b, field, e.Value)
+		}
+		if len(p.KeywordFields) == 0 {
+			return fmt.Errorf("%s: no keyword fields configured", pkgName)
+		}
+		if len(p.KeywordFields) > 1 {
+			b.WriteString("(")
+		}
+		for i, f := range p.KeywordFields {
+			if i > 0 {
+				b.WriteString(" || ")
+			}
+			if err := p.keyword(b, f, e.Value); err != nil {
+				return err
+			}
+		}
+		if len(p.KeywordFields) > 1 {
+			b.WriteString(")")
+		}
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func (p *Printer) join(b *strings.Builder, exprs []ast.Expr, sep string, field string) error {
+	if len(exprs) == 0 {
+		return fmt.Errorf("%s: empty%sexpression", pkgName, sep)
+	}
+	for i, v := range exprs {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		compound := isCompound(v)
+		if compound {
+			b.WriteString("(")
+		}
+		if err := p.expr(b, v, field); err != nil {
+			return err
+		}
+		if compound {
+			b.WriteString(")")
+		}
+	}
+	return nil
+}
+
+// isCompound reports whether expr is printed as several terms joined by && or ||.
+func isCompound(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case ast.And, ast.Or:
+		return true
+	case *ast.ColonExpr:
+		return isCompound(e.Expr)
+	default:
+		return false
+	}
+}
+
+// keyword writes a search of v in field, strings are searched as substrings and others are compared.
+func (p *Printer) keyword(b *strings.Builder, field string, v ast.Value) error {
+	s, err := value(v)
+	if err != nil {
+		return err
+	}
+	if _, ok := v.(ast.StringValue); ok {
+		b.WriteString(field + ".contains(" + s + ")")
+	} else {
+		b.WriteString(field + " == " + s)
+	}
+	return nil
+}
+
+func operator(op ast.Op) (string, error) {
+	switch op {
+	case ast.OpEq:
+		return "==", nil
+	case ast.OpNeq, ast.OpLt, ast.OpLe, ast.OpGt, ast.OpGe:
+		return op.String(), nil
+	default:
+		return "", fmt.Errorf("%s: invalid operator %v", pkgName, op)
+	}
+}
+
+func value(v ast.Value) (string, error) {
+	switch v := v.(type) {
+	case ast.StringValue:
+		return strconv.Quote(string(v)), nil
+	case ast.IntegerValue:
+		return strconv.FormatInt(int64(v), 10), nil
+	case ast.FloatValue:
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("%s: unrepresentable float value %v", pkgName, f)
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		// a literal without "." or an exponent is an int
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s, nil
+	case ast.BoolValue:
+		return strconv.FormatBool(bool(v)), nil
+	case ast.TimeValue:
+		return "timestamp(" + strconv.Quote(time.Time(v).Format(time.RFC3339Nano)) + ")", nil
+	default:
+		return "", fmt.Errorf("%s: unknown value type %T", pkgName, v)
+	}
+}
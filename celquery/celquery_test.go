@@ -0,0 +1,56 @@
+package celquery
+
+import (
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter_Print(t *testing.T) {
+	p := &Printer{KeywordFields: []string{"title", "body"}}
+	cases := []struct {
+		Query    string
+		Expected string
+	}{
+		{
+			`users.type != dogs AND users.type = horses`,
+			`users.type != "dogs" && users.type == "horses"`,
+		},
+		{
+			`NOT cat OR dogs AND horses`,
+			`(!((title.contains("cat") || body.contains("cat"))) || (title.contains("dogs") || body.contains("dogs"))) && (title.contains("horses") || body.contains("horses"))`,
+		},
+		{
+			`color:(red OR white) NOT country:france pages:500`,
+			`(color.contains("red") || color.contains("white")) && !(country.contains("france")) && pages == 500`,
+		},
+		{
+			`create_time > 2020-01-02T03:04:05Z price <= 9.5 pages >= 2.0 available = true`,
+			`create_time > timestamp("2020-01-02T03:04:05Z") && price <= 9.5 && pages >= 2.0 && available == true`,
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		s, err := p.Print(expr)
+		if assert.NoError(t, err, c.Query) {
+			assert.Equal(t, c.Expected, s, c.Query)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			&ast.ColonExpr{Property: "title", Expr: &ast.KeywordExpr{Value: ast.StringValue("dog"), Stem: true}},
+			ast.Or{},
+			&ast.KeywordExpr{Value: ast.StringValue("x")},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+		} {
+			_, err := (&Printer{}).Print(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
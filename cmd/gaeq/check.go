@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"gopkg.in/yaml.v3"
+)
+
+func runCheck(a *app, args []string) int {
+	fs := a.flagSet("check")
+	schemaFile := fs.String("schema", "", "schema file in YAML")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *schemaFile == "" {
+		return a.fail(errors.New("-schema is required"))
+	}
+	schema, err := loadSchema(*schemaFile)
+	if err != nil {
+		return a.fail(err)
+	}
+	qs, err := a.queries(fs.Args())
+	if err != nil {
+		return a.fail(err)
+	}
+	p := searchquery.NewParser(searchquery.WithSchema(schema))
+	code := exitOK
+	for _, q := range qs {
+		if _, err := p.Parse(q.text); err != nil {
+			a.report(q, err)
+			code = exitInvalid
+		}
+	}
+	return code
+}
+
+// schemaFile is the YAML representation of searchquery.Schema:
+//
+//	fields:
+//	  - name: genre
+//	    kind: string
+//	    values: [rock, jazz]
+//	  - name: price
+//	    kind: float
+type schemaFile struct {
+	Fields []struct {
+		Name string `yaml:"name"`
+
+		// Kind is one of time, float, integer, bool or string, empty accepts any value.
+		Kind string `yaml:"kind"`
+
+		Values []string `yaml:"values"`
+	} `yaml:"fields"`
+}
+
+func loadSchema(name string) (*searchquery.Schema, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sf schemaFile
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&sf); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	schema := &searchquery.Schema{}
+	for i, v := range sf.Fields {
+		if v.Name == "" {
+			return nil, fmt.Errorf("%s: field %d: no name", name, i+1)
+		}
+		kind, err := parseKind(v.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("%s: field %q: %v", name, v.Name, err)
+		}
+		schema.Fields = append(schema.Fields, searchquery.Field{
+			Name:   v.Name,
+			Kind:   kind,
+			Values: v.Values,
+		})
+	}
+	return schema, nil
+}
+
+func parseKind(s string) (ast.Kind, error) {
+	if s == "" {
+		return 0, nil
+	}
+	for _, k := range []ast.Kind{ast.KindTime, ast.KindFloat, ast.KindInteger, ast.KindBool, ast.KindString} {
+		if k.String() == s {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown kind %q", s)
+}
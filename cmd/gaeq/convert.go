@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/aipquery"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/kamichidu/go-gae-search-query/blevequery"
+	"github.com/kamichidu/go-gae-search-query/celquery"
+	"github.com/kamichidu/go-gae-search-query/esquery"
+	"github.com/kamichidu/go-gae-search-query/jsonlogicquery"
+	"github.com/kamichidu/go-gae-search-query/lucenequery"
+	"github.com/kamichidu/go-gae-search-query/mongoquery"
+	"github.com/kamichidu/go-gae-search-query/mysqlquery"
+	"github.com/kamichidu/go-gae-search-query/odataquery"
+	"github.com/kamichidu/go-gae-search-query/pgquery"
+	"github.com/kamichidu/go-gae-search-query/sqlitequery"
+)
+
+var (
+	inputFormats = []string{"gae", "lucene", "mongo"}
+
+	outputFormats = []string{"gae", "lucene", "sql", "es", "mongo", "bleve", "aip", "cel", "odata", "jsonlogic"}
+)
+
+// converter reads queries of a format and writes them in another, which convert and repl share.
+type converter struct {
+	from string
+
+	to string
+
+	dialect string
+
+	table string
+
+	defaultField string
+
+	keywordFields []string
+
+	// compact writes outputs in a line, JSON is not indented and SQL arguments follow the SQL.
+	compact bool
+}
+
+func runConvert(a *app, args []string) int {
+	var (
+		c             converter
+		keywordFields string
+	)
+	fs := a.flagSet("convert")
+	fs.StringVar(&c.from, "from", "gae", "input format: "+strings.Join(inputFormats, ", "))
+	fs.StringVar(&c.to, "to", "", "output format: "+strings.Join(outputFormats, ", "))
+	fs.StringVar(&c.dialect, "dialect", "postgres", "SQL dialect: mysql, postgres or sqlite")
+	fs.StringVar(&c.table, "table", "", "FTS5 table of sqlite")
+	fs.StringVar(&c.defaultField, "default-field", "", "default field of lucene and bleve queries")
+	fs.StringVar(&keywordFields, "keyword-fields", "", "comma separated fields or columns searched by keywords")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if keywordFields != "" {
+		c.keywordFields = strings.Split(keywordFields, ",")
+	}
+	if err := c.validate(); err != nil {
+		return a.fail(err)
+	}
+	qs, err := a.queries(fs.Args())
+	if err != nil {
+		return a.fail(err)
+	}
+	code := exitOK
+	for _, q := range qs {
+		expr, err := c.parse(q.text)
+		if err != nil {
+			a.report(q, err)
+			code = exitInvalid
+			continue
+		}
+		s, err := c.convert(expr)
+		if err != nil {
+			a.report(q, err)
+			code = exitInvalid
+			continue
+		}
+		fmt.Fprintln(a.stdout, s)
+	}
+	return code
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// validate reports usage errors of c.
+func (c *converter) validate() error {
+	if !contains(inputFormats, c.from) {
+		return fmt.Errorf("unknown input format %q", c.from)
+	}
+	if !contains(outputFormats, c.to) {
+		return fmt.Errorf("unknown output format %q", c.to)
+	}
+	if c.to != "sql" {
+		return nil
+	}
+	switch c.dialect {
+	case "mysql", "postgres":
+	case "sqlite":
+		if c.table == "" {
+			return errors.New("-table is required for sqlite")
+		}
+	default:
+		return fmt.Errorf("unknown SQL dialect %q", c.dialect)
+	}
+	return nil
+}
+
+// parse parses s of the input format.
+func (c *converter) parse(s string) (ast.Expr, error) {
+	switch c.from {
+	case "gae":
+		return searchquery.Parse(s)
+	case "lucene":
+		return (&lucenequery.Parser{DefaultField: c.defaultField}).Parse(s)
+	case "mongo":
+		return mongoquery.ParseJSON([]byte(s))
+	default:
+		return nil, fmt.Errorf("unknown input format %q", c.from)
+	}
+}
+
+// convert writes expr in the output format.
+func (c *converter) convert(expr ast.Expr) (string, error) {
+	switch c.to {
+	case "gae":
+		return searchquery.Format(expr)
+	case "lucene":
+		return (&lucenequery.Printer{DefaultField: c.defaultField}).Print(expr)
+	case "sql":
+		return c.sql(expr)
+	case "es":
+		m, err := (&esquery.Translator{DefaultFields: c.keywordFields}).Translate(expr)
+		if err != nil {
+			return "", err
+		}
+		return c.marshal(m)
+	case "mongo":
+		d, err := (&mongoquery.Translator{KeywordFields: c.keywordFields}).Translate(expr)
+		if err != nil {
+			return "", err
+		}
+		return c.marshal(d.Map())
+	case "bleve":
+		q, err := (&blevequery.Translator{DefaultField: c.defaultField}).Translate(expr)
+		if err != nil {
+			return "", err
+		}
+		return c.marshal(q)
+	case "aip":
+		return (&aipquery.Printer{}).Print(expr)
+	case "cel":
+		return (&celquery.Printer{KeywordFields: c.keywordFields}).Print(expr)
+	case "odata":
+		return (&odataquery.Printer{KeywordFields: c.keywordFields}).Print(expr)
+	case "jsonlogic":
+		m, err := (&jsonlogicquery.Translator{KeywordFields: c.keywordFields}).Translate(expr)
+		if err != nil {
+			return "", err
+		}
+		return c.marshal(m)
+	default:
+		return "", fmt.Errorf("unknown output format %q", c.to)
+	}
+}
+
+func (c *converter) sql(expr ast.Expr) (string, error) {
+	var (
+		where string
+		args  []interface{}
+		err   error
+	)
+	switch c.dialect {
+	case "mysql":
+		where, args, err = (&mysqlquery.Translator{KeywordColumns: c.keywordFields}).Translate(expr)
+	case "postgres":
+		where, args, err = (&pgquery.Translator{KeywordColumns: c.keywordFields}).Translate(expr)
+	case "sqlite":
+		where, args, err = (&sqlitequery.Translator{Table: c.table, KeywordColumns: c.keywordFields}).Translate(expr)
+	default:
+		return "", fmt.Errorf("unknown SQL dialect %q", c.dialect)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return where, nil
+	}
+	b, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	if c.compact {
+		return fmt.Sprintf("%s -- %s", where, b), nil
+	}
+	return fmt.Sprintf("%s\n-- args: %s", where, b), nil
+}
+
+func (c *converter) marshal(v interface{}) (string, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if !c.compact {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
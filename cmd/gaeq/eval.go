@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/kamichidu/go-gae-search-query/searchindex"
+)
+
+// expressionsFlag collects -expr name=expr.
+type expressionsFlag []searchindex.FieldExpression
+
+func (v *expressionsFlag) String() string {
+	return ""
+}
+
+func (v *expressionsFlag) Set(s string) error {
+	i := strings.IndexByte(s, '=')
+	if i <= 0 {
+		return fmt.Errorf("%q is not name=expr", s)
+	}
+	*v = append(*v, searchindex.FieldExpression{Name: s[:i], Expr: s[i+1:]})
+	return nil
+}
+
+func runEval(a *app, args []string) int {
+	var (
+		docsFile string
+		score    bool
+		exprs    expressionsFlag
+		opts     searchindex.SearchOptions
+	)
+	fs := a.flagSet("eval")
+	fs.StringVar(&docsFile, "docs", "", "documents in JSON lines")
+	fs.IntVar(&opts.Limit, "limit", 20, "maximum number of results")
+	fs.BoolVar(&score, "score", false, "order results by relevance and print scores")
+	fs.Var(&exprs, "expr", "computes name=expr for results, can be repeated")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if docsFile == "" {
+		return a.fail(errors.New("-docs is required"))
+	}
+	index, err := loadDocs(docsFile)
+	if err != nil {
+		return a.fail(err)
+	}
+	qs, err := a.queries(fs.Args())
+	if err != nil {
+		return a.fail(err)
+	}
+	opts.IDsOnly = true
+	opts.Expressions = exprs
+	if score {
+		opts.Scoring = &searchindex.ScoringOptions{}
+	}
+
+	code := exitOK
+	for i, q := range qs {
+		res, err := index.Search(q.text, &opts)
+		if err != nil {
+			a.report(q, err)
+			code = exitInvalid
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(a.stdout)
+		}
+		writeResults(a.stdout, res, score)
+	}
+	return code
+}
+
+func writeResults(w io.Writer, res *searchindex.SearchResult, score bool) {
+	for _, r := range res.Results {
+		fmt.Fprint(w, r.ID)
+		if score {
+			fmt.Fprintf(w, "\t%.4f", r.Score)
+		}
+		for _, f := range r.Expressions {
+			fmt.Fprintf(w, "\t%s=%v", f.Name, f.Value)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// docLine is a line of a docs file. Fields are strings for text fields, numbers, {"atom": s}, {"html": s},
+// {"date": s} or {"geo": [lat, lng]}, or arrays of them for several fields of the name:
+//
+//	{"id": "1", "rank": 3, "fields": {"title": "Harry Potter", "pages": 223, "type": {"atom": "novel"}}}
+type docLine struct {
+	ID string `json:"id"`
+
+	Rank int `json:"rank"`
+
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+func loadDocs(name string) (*searchindex.Index, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	index := searchindex.NewIndex()
+	if err := putDocs(index, name, f); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func putDocs(index *searchindex.Index, source string, r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var line docLine
+		if err := json.Unmarshal(sc.Bytes(), &line); err != nil {
+			return fmt.Errorf("%s:%d: %v", source, n, err)
+		}
+		doc := &searchindex.Document{Rank: line.Rank}
+		names := make([]string, 0, len(line.Fields))
+		for name := range line.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			values, err := fieldValues(line.Fields[name])
+			if err != nil {
+				return fmt.Errorf("%s:%d: field %q: %v", source, n, name, err)
+			}
+			for _, v := range values {
+				doc.Fields = append(doc.Fields, searchindex.Field{Name: name, Value: v})
+			}
+		}
+		if _, err := index.Put(line.ID, doc); err != nil {
+			return fmt.Errorf("%s:%d: %v", source, n, err)
+		}
+	}
+	return sc.Err()
+}
+
+func fieldValues(raw json.RawMessage) ([]interface{}, error) {
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		list = []json.RawMessage{raw}
+	}
+	var values []interface{}
+	for _, raw := range list {
+		v, err := fieldValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func fieldValue(raw json.RawMessage) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return v, nil
+	case map[string]interface{}:
+		if len(v) != 1 {
+			break
+		}
+		for k, x := range v {
+			switch k {
+			case "atom":
+				if s, ok := x.(string); ok {
+					return searchindex.Atom(s), nil
+				}
+			case "html":
+				if s, ok := x.(string); ok {
+					return searchindex.HTML(s), nil
+				}
+			case "date":
+				if s, ok := x.(string); ok {
+					return parseDate(s)
+				}
+			case "geo":
+				if p, ok := x.([]interface{}); ok && len(p) == 2 {
+					lat, ok1 := p[0].(float64)
+					lng, ok2 := p[1].(float64)
+					if ok1 && ok2 {
+						return searchindex.GeoPoint{Lat: lat, Lng: lng}, nil
+					}
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("unsupported value %s", raw)
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range ast.TimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
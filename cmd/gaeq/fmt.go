@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+)
+
+func runFmt(a *app, args []string) int {
+	fs := a.flagSet("fmt")
+	write := fs.Bool("w", false, "write results to the files instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		if *write {
+			return a.fail(errors.New("-w needs files"))
+		}
+		out, ok, err := a.formatLines("stdin", a.stdin)
+		if err != nil {
+			return a.fail(err)
+		}
+		io.WriteString(a.stdout, out)
+		if !ok {
+			return exitInvalid
+		}
+		return exitOK
+	}
+
+	code := exitOK
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			return a.fail(err)
+		}
+		out, ok, err := a.formatLines(name, f)
+		f.Close()
+		if err != nil {
+			return a.fail(err)
+		}
+		if !ok {
+			code = exitInvalid
+			continue
+		}
+		if !*write {
+			io.WriteString(a.stdout, out)
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return a.fail(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(out), info.Mode()); err != nil {
+			return a.fail(err)
+		}
+	}
+	return code
+}
+
+// formatLines formats each line of r as a query, blank lines and comments starting with # are kept as is, and
+// so are comments after queries. ok is false if any query is invalid, which are reported.
+func (a *app) formatLines(source string, r io.Reader) (out string, ok bool, err error) {
+	var b strings.Builder
+	ok = true
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if s := strings.TrimSpace(line); s == "" || strings.HasPrefix(s, "#") {
+			b.WriteString(line + "\n")
+			continue
+		}
+		q := query{source: source, line: n, text: line}
+		expr, err := searchquery.Parse(line)
+		if err == nil {
+			line, err = searchquery.Format(expr)
+		}
+		if err != nil {
+			a.report(q, err)
+			ok = false
+			continue
+		}
+		if comment := trailingComment(q.text); comment != "" {
+			line += " " + comment
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String(), ok, sc.Err()
+}
+
+// trailingComment returns the comment at the end of a valid query s, if any.
+func trailingComment(s string) string {
+	// s has been parsed, tokenizing all of it costs no more than that
+	tokens := searchquery.Tokenize(s, searchquery.WithMaxInputBytes(len(s)))
+	for i := len(tokens) - 1; i >= 0; i-- {
+		switch tokens[i].Kind {
+		case searchquery.TokenWhitespace:
+		case searchquery.TokenComment:
+			return tokens[i].Text
+		default:
+			return ""
+		}
+	}
+	return ""
+}
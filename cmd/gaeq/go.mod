@@ -0,0 +1,12 @@
+module github.com/kamichidu/go-gae-search-query/cmd/gaeq
+
+go 1.14
+
+require (
+	github.com/kamichidu/go-gae-search-query v0.0.0-20261019054831-ab1e8e5f1e68
+	github.com/kamichidu/go-gae-search-query/blevequery v0.0.0-20261019054915-3fb8a0e66ae3
+	github.com/kamichidu/go-gae-search-query/sqlitequery v0.0.0-20261019054915-3fb8a0e66ae3
+	github.com/peterh/liner v1.2.1
+	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
+)
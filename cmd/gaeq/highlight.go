@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+)
+
+func runHighlight(a *app, args []string) int {
+	fs := a.flagSet("highlight")
+	format := fs.String("format", "ansi", "output format, ansi or html")
+	class := fs.String("class", "q-", "prefix of HTML classes")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	var highlight func(string) string
+	switch *format {
+	case "ansi":
+		highlight = searchquery.HighlightANSI
+	case "html":
+		highlight = func(s string) string {
+			return searchquery.HighlightHTML(s, *class)
+		}
+	default:
+		return a.fail(fmt.Errorf("unknown format %q", *format))
+	}
+	qs, err := a.queries(fs.Args())
+	if err != nil {
+		return a.fail(err)
+	}
+	for _, q := range qs {
+		fmt.Fprintln(a.stdout, highlight(q.text))
+	}
+	return exitOK
+}
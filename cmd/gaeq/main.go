@@ -0,0 +1,158 @@
+// Command gaeq parses, formats, checks, converts, evaluates and highlights GAE Search API queries.
+//
+//	gaeq parse -format tree 'title:potter AND pages < 500'
+//	gaeq fmt -w queries.txt
+//	gaeq check -schema schema.yaml 'genre:rock'
+//	gaeq convert -to sql -dialect mysql 'pages < 500'
+//	echo '{"pages": {"$lt": 500}}' | gaeq convert -from mongo -to lucene
+//	gaeq eval -docs docs.jsonl 'potter'
+//	gaeq repl -docs docs.jsonl
+//	gaeq highlight -format html 'title:potter'
+//
+// Queries are the arguments joined by spaces, or the lines of stdin if there is none, blank lines are
+// skipped. gaeq exits with 1 if any query is invalid, and with 2 on usage or I/O errors.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+)
+
+const (
+	pkgName = "gaeq"
+)
+
+const (
+	exitOK = iota
+	exitInvalid
+	exitUsage
+)
+
+type command struct {
+	name string
+
+	usage string
+
+	run func(app *app, args []string) int
+}
+
+var commands = []*command{
+	{"parse", "parse [-format json|tree] [query]", runParse},
+	{"fmt", "fmt [-w] [file...]", runFmt},
+	{"check", "check -schema schema.yaml [query]", runCheck},
+	{"convert", "convert [-from gae|lucene|mongo] -to format [flags] [query]", runConvert},
+	{"eval", "eval -docs docs.jsonl [flags] [query]", runEval},
+	{"repl", "repl [-schema schema.yaml] [-docs docs.jsonl]", runRepl},
+	{"highlight", "highlight [-format ansi|html] [-class prefix] [query]", runHighlight},
+}
+
+// app holds the streams of a run, so that commands can be run in tests.
+type app struct {
+	stdin io.Reader
+
+	stdout, stderr io.Writer
+}
+
+func main() {
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(a.run(os.Args[1:]))
+}
+
+func (a *app) run(args []string) int {
+	if len(args) == 0 {
+		a.usage()
+		return exitUsage
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(a, args[1:])
+		}
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		a.usage()
+		return exitOK
+	}
+	fmt.Fprintf(a.stderr, "%s: unknown command %q\n", pkgName, args[0])
+	a.usage()
+	return exitUsage
+}
+
+func (a *app) usage() {
+	fmt.Fprintf(a.stderr, "usage:\n")
+	for _, c := range commands {
+		fmt.Fprintf(a.stderr, "\t%s %s\n", pkgName, c.usage)
+	}
+}
+
+func (a *app) flagSet(c string) *flag.FlagSet {
+	fs := flag.NewFlagSet(pkgName+" "+c, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// query is a query with where it came from.
+type query struct {
+	source string
+
+	line int
+
+	text string
+}
+
+// queries returns the arguments as a query, or the lines of stdin.
+func (a *app) queries(args []string) ([]query, error) {
+	if len(args) > 0 {
+		return []query{{source: "args", line: 1, text: strings.Join(args, " ")}}, nil
+	}
+	return readQueries("stdin", a.stdin)
+}
+
+func readQueries(source string, r io.Reader) ([]query, error) {
+	var qs []query
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		qs = append(qs, query{source: source, line: n, text: sc.Text()})
+	}
+	return qs, sc.Err()
+}
+
+// report writes err of q, with the position and a caret under the query if err has an offset.
+func (a *app) report(q query, err error) {
+	var (
+		serr   *searchquery.SyntaxError
+		lerr   *searchquery.LimitError
+		verr   *searchquery.ValueError
+		offset = -1
+	)
+	switch {
+	case errors.As(err, &serr):
+		offset = serr.Offset
+	case errors.As(err, &lerr):
+		offset = lerr.Offset
+	case errors.As(err, &verr):
+		offset = verr.Offset
+	}
+	if offset < 0 || offset > len(q.text) {
+		fmt.Fprintf(a.stderr, "%s:%d: %v\n", q.source, q.line, err)
+		return
+	}
+	col := utf8.RuneCountInString(q.text[:offset]) + 1
+	fmt.Fprintf(a.stderr, "%s:%d:%d: %v\n", q.source, q.line, col, err)
+	fmt.Fprintf(a.stderr, "\t%s\n\t%s^\n", q.text, strings.Repeat(" ", col-1))
+}
+
+func (a *app) fail(err error) int {
+	fmt.Fprintf(a.stderr, "%s: %v\n", pkgName, err)
+	return exitUsage
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runApp(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	a := &app{stdin: strings.NewReader(stdin), stdout: &out, stderr: &errOut}
+	code = a.run(args)
+	return code, out.String(), errOut.String()
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestParse(t *testing.T) {
+	code, out, _ := runApp("", "parse", "-format", "tree", "title:potter AND NOT", "pages", "<", "500")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "AND\n  title:\n    \"potter\" (string)\n  NOT\n    pages < 500 (integer)\n", out)
+
+	code, out, _ = runApp("blue\n\n~red\n", "parse")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "{\n  \"keyword\": {\n    \"value\": {\n      \"S\": \"blue\"\n    }\n  }\n}\n"+
+		"{\n  \"keyword\": {\n    \"stem\": true,\n    \"value\": {\n      \"S\": \"red\"\n    }\n  }\n}\n", out)
+
+	code, out, errOut := runApp("ok\ntitle:(potter\n", "parse", "-format", "tree")
+	assert.Equal(t, exitInvalid, code)
+	assert.Equal(t, "\"ok\" (string)\n", out)
+	assert.Equal(t, "stdin:2:14: searchquery: syntax error at offset 13\n\ttitle:(potter\n\t             ^\n", errOut)
+
+	code, _, errOut = runApp("date < 2020-13-45\nn = 99999999999999999999\n", "parse")
+	assert.Equal(t, exitInvalid, code)
+	assert.Equal(t, "stdin:1:8: searchquery: invalid value 2020-13-45 at offset 7: parsing time \"2020-13-45\": month out of range\n"+
+		"\tdate < 2020-13-45\n\t       ^\n"+
+		"stdin:2:5: searchquery: invalid value 99999999999999999999 at offset 4: value out of range\n"+
+		"\tn = 99999999999999999999\n\t    ^\n", errOut)
+
+	code, _, _ = runApp("", "parse", "-format", "xml", "x")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runApp("")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runApp("", "unknown")
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestFmt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gaeq")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	good := writeFile(t, dir, "good.txt", "# queries\nblue   guitar # best sellers\n\ncolor:( red OR white )\n")
+	bad := writeFile(t, dir, "bad.txt", "blue\npages <\ndate < 2020-13-45\nn = 99999999999999999999\n")
+
+	code, out, _ := runApp("", "fmt", good)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "# queries\nblue AND guitar # best sellers\n\ncolor:(red OR white)\n", out)
+
+	code, _, _ = runApp("", "fmt", "-w", good)
+	assert.Equal(t, exitOK, code)
+	b, err := ioutil.ReadFile(good)
+	require.NoError(t, err)
+	assert.Equal(t, "# queries\nblue AND guitar # best sellers\n\ncolor:(red OR white)\n", string(b))
+
+	code, _, errOut := runApp("", "fmt", "-w", bad)
+	assert.Equal(t, exitInvalid, code)
+	assert.Contains(t, errOut, "bad.txt:2:8: ")
+	assert.Contains(t, errOut, "bad.txt:3:8: searchquery: invalid value 2020-13-45")
+	assert.Contains(t, errOut, "bad.txt:4:5: searchquery: invalid value 99999999999999999999")
+	b, err = ioutil.ReadFile(bad)
+	require.NoError(t, err)
+	assert.Equal(t, "blue\npages <\ndate < 2020-13-45\nn = 99999999999999999999\n", string(b))
+
+	code, out, _ = runApp("a   b\n", "fmt")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "a AND b\n", out)
+
+	code, _, _ = runApp("", "fmt", "-w")
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gaeq")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	schema := writeFile(t, dir, "schema.yaml", `fields:
+  - name: genre
+    kind: string
+    values: [rock, jazz]
+  - name: price
+    kind: float
+`)
+	code, _, errOut := runApp("genre:rock price < 10\ngenre:pop\nrating > 3\n", "check", "-schema", schema)
+	assert.Equal(t, exitInvalid, code)
+	assert.Equal(t, []string{
+		`stdin:2: searchquery: property "genre": value "pop" is not one of ["rock" "jazz"]`,
+		`stdin:3: searchquery: property "rating": unknown property`,
+	}, strings.Split(strings.TrimSpace(errOut), "\n"))
+
+	code, _, _ = runApp("", "check", "-schema", schema, "price:1.5")
+	assert.Equal(t, exitOK, code)
+
+	invalid := writeFile(t, dir, "invalid.yaml", "fields:\n  - name: genre\n    kind: text\n")
+	code, _, _ = runApp("", "check", "-schema", invalid, "genre:rock")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runApp("", "check", "genre:rock")
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		Args     []string
+		Expected string
+	}{
+		{[]string{"-to", "lucene", "title:potter AND pages < 500"}, "(title:potter AND pages:{* TO 500})\n"},
+		{[]string{"-to", "sql", "pages < 500"}, "\"pages\" < $1\n-- args: [500]\n"},
+		{[]string{"-to", "sql", "-dialect", "mysql", "pages < 500"}, "`pages` < ?\n-- args: [500]\n"},
+		{[]string{"-to", "mongo", "pages < 500"}, "{\n  \"pages\": {\n    \"$lt\": 500\n  }\n}\n"},
+		{[]string{"-to", "es", "pages < 500"}, "{\n  \"range\": {\n    \"pages\": {\n      \"lt\": 500\n    }\n  }\n}\n"},
+		{[]string{"-to", "sql", "-dialect", "sqlite", "-table", "docs", "potter"}, "rowid IN (SELECT rowid FROM \"docs\" WHERE \"docs\" MATCH ?)\n-- args: [\"\\\"potter\\\"\"]\n"},
+		{[]string{"-to", "aip", "pages < 500"}, "pages < 500\n"},
+		{[]string{"-to", "cel", "pages < 500"}, "pages < 500\n"},
+		{[]string{"-to", "odata", "pages < 500"}, "pages lt 500\n"},
+		{[]string{"-to", "jsonlogic", "pages < 500"}, "{\n  \"<\": [\n    {\n      \"var\": \"pages\"\n    },\n    500\n  ]\n}\n"},
+		{[]string{"-to", "bleve", "-default-field", "body", "potter"}, "{\n  \"match\": \"potter\",\n  \"field\": \"body\",\n  \"prefix_length\": 0,\n  \"fuzziness\": 0\n}\n"},
+		{[]string{"-from", "lucene", "-to", "gae", "title:potter AND pages:[* TO 500}"}, "title:potter AND pages < 500\n"},
+		{[]string{"-from", "mongo", "-to", "gae", `{"pages": {"$lt": 500}}`}, "pages < 500\n"},
+	}
+	for _, c := range cases {
+		code, out, errOut := runApp("", append([]string{"convert"}, c.Args...)...)
+		assert.Equal(t, exitOK, code, "%v: %s", c.Args, errOut)
+		assert.Equal(t, c.Expected, out, "%v", c.Args)
+	}
+
+	code, _, _ := runApp("", "convert", "-to", "xml", "x")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runApp("", "convert", "-to", "sql", "-dialect", "oracle", "x")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runApp("", "convert", "-to", "sql", "-dialect", "sqlite", "x")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runApp("", "convert", "-from", "sql", "-to", "gae", "x")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runApp("", "convert", "-to", "lucene", "(x")
+	assert.Equal(t, exitInvalid, code)
+	code, _, errOut := runApp("", "convert", "-from", "mongo", "-to", "gae", `{"$where": "x"}`)
+	assert.Equal(t, exitInvalid, code)
+	assert.Contains(t, errOut, "unsupported operator $where")
+
+	code, out, _ := runApp(`{"a": 1}`+"\n"+`{"b": {"$gt": 2}}`+"\n", "convert", "-from", "mongo", "-to", "lucene")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "a:1\nb:{2 TO *}\n", out)
+}
+
+func TestEval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gaeq")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	docs := writeFile(t, dir, "docs.jsonl", `{"id": "1", "rank": 3, "fields": {"title": "Harry Potter", "pages": 223, "type": {"atom": "novel"}}}
+
+{"id": "2", "rank": 2, "fields": {"title": "The Hobbit", "type": [{"atom": "novel"}, {"atom": "fantasy"}], "published": {"date": "1937-09-21"}}}
+{"id": "3", "rank": 1, "fields": {"title": "Field of the Potter", "store": {"geo": [35.6, 139.7]}}}
+`)
+	code, out, _ := runApp("potter\ntype:novel\n", "eval", "-docs", docs)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "1\n3\n\n1\n2\n", out)
+
+	code, out, _ = runApp("", "eval", "-docs", docs, "-expr", "n=count(type)", "published < 1950-01-01 OR pages > 200")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "1\tn=1\n2\tn=2\n", out)
+
+	code, out, _ = runApp("", "eval", "-docs", docs, "-score", "-limit", "1", "potter")
+	assert.Equal(t, exitOK, code)
+	assert.Regexp(t, `^1\t\d+\.\d{4}\n$`, out)
+
+	code, _, errOut := runApp("", "eval", "-docs", docs, "(potter")
+	assert.Equal(t, exitInvalid, code)
+	assert.Contains(t, errOut, "args:1:8: ")
+
+	invalid := writeFile(t, dir, "invalid.jsonl", `{"id": "1", "fields": {"x": true}}`)
+	code, _, errOut = runApp("", "eval", "-docs", invalid, "x")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, errOut, `invalid.jsonl:1: field "x": unsupported value true`)
+}
+
+func TestHighlight(t *testing.T) {
+	code, out, _ := runApp("", "highlight", "-format", "html", "a", "<", "1")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, `<span class="q-property">a</span> <span class="q-operator">&lt;</span> <span class="q-integer">1</span>`+"\n", out)
+
+	code, out, _ = runApp("x:y\n", "highlight")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "\x1b[36mx\x1b[0m\x1b[33m:\x1b[0my\n", out)
+
+	code, _, _ = runApp("", "highlight", "-format", "xml", "x")
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestRepl(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gaeq")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	schema := writeFile(t, dir, "schema.yaml", "fields:\n  - name: pages\n    kind: integer\n")
+	docs := writeFile(t, dir, "docs.jsonl", `{"id": "1", "fields": {"title": "Harry Potter", "pages": 223}}
+{"id": "2", "fields": {"title": "The Hobbit", "pages": 310}}
+`)
+	input := strings.Join([]string{
+		"pages < 300",
+		":load " + docs,
+		":keywords title",
+		"potter",
+		":schema " + schema,
+		":schema",
+		"title:potter",
+		":schema off",
+		":trace",
+		"x",
+		":trace off",
+		"(x",
+		"date < 2020-13-45",
+		":unknown",
+		":quit",
+		"never read",
+	}, "\n")
+	code, out, errOut := runApp(input, "repl")
+	assert.Equal(t, exitOK, code)
+
+	assert.Contains(t, out, "tree:\n  pages < 300 (integer)\n")
+	assert.Contains(t, out, "gae:       pages < 300\n")
+	assert.Contains(t, out, "sql:       \"pages\" < $1 -- [300]\n")
+	assert.Contains(t, out, "mongo:     {\"pages\":{\"$lt\":300}}\n")
+	assert.Contains(t, out, "jsonlogic: {\"<\":[{\"var\":\"pages\"},300]}\n")
+	assert.Contains(t, out, "loaded 2 documents\n")
+	assert.Contains(t, out, "keyword fields: title\n")
+	assert.Contains(t, out, "cel:       title.contains(\"potter\")\n")
+	assert.Contains(t, out, "matches:   1 [1]\n")
+	assert.Contains(t, out, "loaded 1 fields\n")
+	assert.Contains(t, out, "pages\tinteger\t\n")
+	assert.Contains(t, out, "trace: true\n")
+	assert.Contains(t, out, "trace: pushKeywordExpr\n")
+	assert.NotContains(t, out, "never read")
+
+	assert.Contains(t, errOut, "repl:7: searchquery: property \"title\": unknown property\n")
+	assert.Contains(t, errOut, "repl:12:3: searchquery: syntax error at offset 2\n")
+	assert.Contains(t, errOut, "repl:13:8: searchquery: invalid value 2020-13-45 at offset 7")
+	assert.Contains(t, errOut, "gaeq: unknown command :unknown, see :help\n")
+}
+
+func TestRepl_Complete(t *testing.T) {
+	r := &repl{schema: &searchquery.Schema{Fields: []searchquery.Field{{Name: "genre", Values: []string{"rock", "jazz"}}}}}
+	head, completions, tail := r.complete("ジャズ OR genre:r x", 14)
+	assert.Equal(t, "ジャズ OR genre:", head)
+	assert.Equal(t, []string{"rock"}, completions)
+	assert.Equal(t, " x", tail)
+
+	head, completions, tail = r.complete(":load x", 3)
+	assert.Equal(t, ":lo", head)
+	assert.Empty(t, completions)
+	assert.Equal(t, "ad x", tail)
+}
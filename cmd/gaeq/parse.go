@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+func runParse(a *app, args []string) int {
+	fs := a.flagSet("parse")
+	format := fs.String("format", "json", "output format: json or tree")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *format != "json" && *format != "tree" {
+		return a.fail(fmt.Errorf("unknown format %q", *format))
+	}
+	qs, err := a.queries(fs.Args())
+	if err != nil {
+		return a.fail(err)
+	}
+	code := exitOK
+	for _, q := range qs {
+		expr, err := searchquery.Parse(q.text)
+		if err != nil {
+			a.report(q, err)
+			code = exitInvalid
+			continue
+		}
+		if *format == "tree" {
+			writeTree(a.stdout, expr, 0)
+			continue
+		}
+		b, err := json.MarshalIndent(expr, "", "  ")
+		if err != nil {
+			return a.fail(err)
+		}
+		fmt.Fprintf(a.stdout, "%s\n", b)
+	}
+	return code
+}
+
+// writeTree writes expr as an indented tree, a node per line.
+func writeTree(w io.Writer, expr ast.Expr, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch e := expr.(type) {
+	case ast.And:
+		fmt.Fprintf(w, "%sAND\n", indent)
+		for _, v := range e {
+			writeTree(w, v, depth+1)
+		}
+	case ast.Or:
+		fmt.Fprintf(w, "%sOR\n", indent)
+		for _, v := range e {
+			writeTree(w, v, depth+1)
+		}
+	case *ast.Not:
+		fmt.Fprintf(w, "%sNOT\n", indent)
+		writeTree(w, e.Expr, depth+1)
+	case *ast.OperatorExpr:
+		fmt.Fprintf(w, "%s%s %s %s\n", indent, e.Property, e.Operator, valueString(e.Value))
+	case *ast.ColonExpr:
+		fmt.Fprintf(w, "%s%s:\n", indent, e.Property)
+		writeTree(w, e.Expr, depth+1)
+	case *ast.KeywordExpr:
+		stem := ""
+		if e.Stem {
+			stem = "~"
+		}
+		fmt.Fprintf(w, "%s%s%s\n", indent, stem, valueString(e.Value))
+	default:
+		fmt.Fprintf(w, "%s%T\n", indent, expr)
+	}
+}
+
+// valueString returns v with its kind, e.g. "potter" (string) or 500 (integer).
+func valueString(v ast.Value) string {
+	var s string
+	switch v := v.(type) {
+	case ast.StringValue:
+		s = strconv.Quote(string(v))
+	case ast.TimeValue:
+		s = time.Time(v).Format(time.RFC3339Nano)
+	default:
+		s = fmt.Sprint(v)
+	}
+	return fmt.Sprintf("%s (%s)", s, v.Kind())
+}
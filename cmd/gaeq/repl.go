@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/kamichidu/go-gae-search-query/searchindex"
+	"github.com/peterh/liner"
+)
+
+const replHelp = `Enter a query to see its tree, normalized form and translations.
+	:schema [file|off]   shows, loads or clears the schema which queries are checked against
+	:load file           loads documents in JSON lines, which queries are evaluated against
+	:keywords [fields]   sets comma separated fields searched by keywords, shows them without fields
+	:trace [on|off]      toggles the parser trace
+	:help                shows this help
+	:quit                exits
+`
+
+// lineReader reads lines with line editing and history on terminals.
+type lineReader interface {
+	Prompt(prompt string) (string, error)
+
+	AppendHistory(line string)
+
+	Close() error
+}
+
+// scanReader reads lines of non-interactive input, without prompts.
+type scanReader struct {
+	sc *bufio.Scanner
+}
+
+func (r *scanReader) Prompt(string) (string, error) {
+	if !r.sc.Scan() {
+		if err := r.sc.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.sc.Text(), nil
+}
+
+func (r *scanReader) AppendHistory(string) {}
+
+func (r *scanReader) Close() error {
+	return nil
+}
+
+type repl struct {
+	*app
+
+	schema *searchquery.Schema
+
+	index *searchindex.Index
+
+	keywordFields []string
+
+	trace bool
+
+	line int
+}
+
+func runRepl(a *app, args []string) int {
+	fs := a.flagSet("repl")
+	schemaFile := fs.String("schema", "", "schema file in YAML")
+	docsFile := fs.String("docs", "", "documents in JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	r := &repl{app: a}
+	if *schemaFile != "" {
+		if err := r.command(":schema " + *schemaFile); err != nil {
+			return a.fail(err)
+		}
+	}
+	if *docsFile != "" {
+		if err := r.command(":load " + *docsFile); err != nil {
+			return a.fail(err)
+		}
+	}
+
+	var lr lineReader
+	if a.stdin == os.Stdin {
+		st := liner.NewLiner()
+		st.SetCtrlCAborts(true)
+		st.SetWordCompleter(r.complete)
+		historyFile := ""
+		if dir, err := os.UserHomeDir(); err == nil {
+			historyFile = filepath.Join(dir, ".gaeq_history")
+			if f, err := os.Open(historyFile); err == nil {
+				st.ReadHistory(f)
+				f.Close()
+			}
+		}
+		defer func() {
+			if historyFile == "" {
+				return
+			}
+			if f, err := os.Create(historyFile); err == nil {
+				st.WriteHistory(f)
+				f.Close()
+			}
+		}()
+		lr = st
+		fmt.Fprintf(a.stdout, "Type :help for commands.\n")
+	} else {
+		lr = &scanReader{sc: bufio.NewScanner(a.stdin)}
+	}
+	defer lr.Close()
+
+	for {
+		line, err := lr.Prompt("gaeq> ")
+		if err == liner.ErrPromptAborted {
+			continue
+		} else if err == io.EOF {
+			return exitOK
+		} else if err != nil {
+			return a.fail(err)
+		}
+		r.line++
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lr.AppendHistory(line)
+		if !strings.HasPrefix(line, ":") {
+			r.query(line)
+			continue
+		}
+		if line == ":quit" || line == ":q" || line == ":exit" {
+			return exitOK
+		}
+		if err := r.command(line); err != nil {
+			fmt.Fprintf(a.stderr, "%s: %v\n", pkgName, err)
+		}
+	}
+}
+
+func (r *repl) command(line string) error {
+	fields := strings.Fields(line)
+	arg := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+	switch fields[0] {
+	case ":help":
+		io.WriteString(r.stdout, replHelp)
+	case ":schema":
+		switch arg {
+		case "":
+			if r.schema == nil {
+				fmt.Fprintf(r.stdout, "no schema\n")
+			} else {
+				for _, f := range r.schema.Fields {
+					fmt.Fprintf(r.stdout, "%s\t%s\t%s\n", f.Name, kindName(f.Kind), strings.Join(f.Values, ","))
+				}
+			}
+		case "off":
+			r.schema = nil
+		default:
+			schema, err := loadSchema(arg)
+			if err != nil {
+				return err
+			}
+			r.schema = schema
+			fmt.Fprintf(r.stdout, "loaded %d fields\n", len(schema.Fields))
+		}
+	case ":load":
+		if arg == "" {
+			return errors.New(":load needs a file")
+		}
+		index, err := loadDocs(arg)
+		if err != nil {
+			return err
+		}
+		res, err := index.Search("", &searchindex.SearchOptions{IDsOnly: true, Limit: 1})
+		if err != nil {
+			return err
+		}
+		r.index = index
+		fmt.Fprintf(r.stdout, "loaded %d documents\n", res.Count)
+	case ":keywords":
+		if arg != "" {
+			r.keywordFields = strings.Split(arg, ",")
+		}
+		fmt.Fprintf(r.stdout, "keyword fields: %s\n", strings.Join(r.keywordFields, ","))
+	case ":trace":
+		switch arg {
+		case "":
+			r.trace = !r.trace
+		case "on", "off":
+			r.trace = arg == "on"
+		default:
+			return fmt.Errorf("unknown argument %q of :trace", arg)
+		}
+		fmt.Fprintf(r.stdout, "trace: %v\n", r.trace)
+	default:
+		return fmt.Errorf("unknown command %s, see :help", fields[0])
+	}
+	return nil
+}
+
+// complete completes the query at the cursor pos in runes of line, with the schema if loaded.
+func (r *repl) complete(line string, pos int) (head string, completions []string, tail string) {
+	off := len(string([]rune(line)[:pos]))
+	var suggestions []searchquery.Suggestion
+	if !strings.HasPrefix(line, ":") {
+		suggestions = searchquery.Complete(line, off, r.schema)
+	}
+	if len(suggestions) == 0 {
+		return line[:off], nil, line[off:]
+	}
+	for _, v := range suggestions {
+		completions = append(completions, v.Text)
+	}
+	return line[:suggestions[0].Start], completions, line[off:]
+}
+
+func kindName(k ast.Kind) string {
+	if k == 0 {
+		return "any"
+	}
+	return k.String()
+}
+
+// query shows the tree, the normalized form, translations and matched documents of s.
+func (r *repl) query(s string) {
+	var opts []searchquery.ParseOption
+	if r.schema != nil {
+		opts = append(opts, searchquery.WithSchema(r.schema))
+	}
+	if r.trace {
+		opts = append(opts, searchquery.WithLogger(log.New(r.stdout, "", 0)))
+	}
+	expr, err := searchquery.ParseWithOptions(s, opts...)
+	if err != nil {
+		r.report(query{source: "repl", line: r.line, text: s}, err)
+		return
+	}
+
+	fmt.Fprintf(r.stdout, "tree:\n")
+	writeTree(r.stdout, expr, 1)
+	for _, to := range outputFormats {
+		c := &converter{to: to, dialect: "postgres", keywordFields: r.keywordFields, compact: true}
+		out, err := c.convert(expr)
+		if err != nil {
+			out = "error: " + err.Error()
+		}
+		fmt.Fprintf(r.stdout, "%-10s %s\n", to+":", out)
+	}
+	if r.index != nil {
+		res, err := r.index.Search(s, &searchindex.SearchOptions{IDsOnly: true, Scoring: &searchindex.ScoringOptions{}})
+		if err != nil {
+			fmt.Fprintf(r.stdout, "%-10s error: %v\n", "matches:", err)
+			return
+		}
+		ids := make([]string, len(res.Results))
+		for i, v := range res.Results {
+			ids[i] = v.ID
+		}
+		fmt.Fprintf(r.stdout, "%-10s %d [%s]\n", "matches:", res.Count, strings.Join(ids, " "))
+	}
+}
@@ -0,0 +1,333 @@
+package searchquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// SuggestionKind classifies what a Suggestion completes.
+type SuggestionKind int
+
+const (
+	SuggestProperty SuggestionKind = iota + 1
+	SuggestOperator
+	SuggestValue
+	SuggestKeyword
+	SuggestParen
+)
+
+func (v SuggestionKind) String() string {
+	switch v {
+	case SuggestProperty:
+		return "property"
+	case SuggestOperator:
+		return "operator"
+	case SuggestValue:
+		return "value"
+	case SuggestKeyword:
+		return "keyword"
+	case SuggestParen:
+		return "paren"
+	default:
+		return fmt.Sprintf("SuggestionKind(%d)", int(v))
+	}
+}
+
+// Suggestion is a text which can be put at the cursor.
+type Suggestion struct {
+	Kind SuggestionKind
+
+	Text string
+
+	// Start and End are the byte offsets of the input which Text replaces, the partial word before the
+	// cursor, or both at the cursor if there is none.
+	Start, End int
+}
+
+// Complete returns suggestions for the cursor at byte offset pos of the partial query input, as expected by
+// the grammar there: property names, operators applicable to the property, allowed values of the property,
+// AND, OR and NOT, or closing parentheses. Properties and values are taken from schema, which may be nil.
+// Input after pos is ignored, and input before pos need not be a valid query. Of opts, only WithMaxInputBytes
+// applies, which limits pos to 2000 by default, there are no suggestions beyond it.
+func Complete(input string, pos int, schema *Schema, opts ...ParseOption) []Suggestion {
+	if pos < 0 {
+		pos = 0
+	} else if pos > len(input) {
+		pos = len(input)
+	}
+	if pos > inputLimit(opts) {
+		return nil
+	}
+	c := &completer{schema: schema, start: pos, end: pos}
+
+	segments := parsePartial(input[:pos])
+	last := &segments[len(segments)-1]
+	if tokens := last.at(pos, partialRules...); len(tokens) > 0 {
+		t := tokens[0]
+		c.start = last.offsets[t.begin]
+		c.prefix = input[c.start:pos]
+		switch t.pegRule {
+		case ruleQuotedString:
+			if len(last.at(pos, ruleExpectQuote)) == 0 {
+				// the quoted string is closed
+				c.start, c.prefix = pos, ""
+				break
+			}
+			c.prefix = unquotePartial(c.prefix)
+			c.quoted = true
+		case ruleOperator, rulePartialOperator:
+			if c.prefix != "<" && c.prefix != ">" && c.prefix != "!" {
+				c.start, c.prefix = pos, ""
+				break
+			}
+			c.operators(newCompletionState(input[:c.start]).property)
+			return c.suggestions
+		}
+	} else if n := len(segments); n > 1 && last.start == pos && segments[n-2].end < pos {
+		// the grammar accepts nothing of the word before the cursor, e.g. a word of non-ASCII characters
+		c.start = segments[n-2].end
+		c.prefix = input[c.start:pos]
+	}
+
+	st := newCompletionState(input[:c.start])
+	switch st.expect {
+	case expectExpr:
+		c.expr(st)
+	case expectOperator:
+		if c.prefix != "" {
+			// the property turned out to be a keyword
+			c.afterExpr(st)
+			break
+		}
+		c.operators(st.property)
+		c.afterExpr(st)
+	case expectValue:
+		c.values(st.property)
+	case expectString:
+		c.values(st.field)
+	case expectAfterExpr:
+		c.afterExpr(st)
+	}
+	return c.suggestions
+}
+
+var allOperators = []string{":", "=", "!=", "<", "<=", ">", ">="}
+
+// partialRules are the rules of words which may continue after the cursor.
+var partialRules = []pegRule{
+	ruleProperty, ruleOperator, rulePartialOperator, ruleTime, ruleFloat, ruleInteger, ruleBool, ruleBareString,
+	ruleQuotedString, ruleAnd, ruleOr, ruleNot,
+}
+
+func unquotePartial(s string) string {
+	s = strings.TrimPrefix(s, `"`)
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+type expectation int
+
+const (
+	expectExpr expectation = iota
+	expectOperator
+	expectValue
+	expectString
+	expectAfterExpr
+)
+
+// completionState is what the grammar expects at the end of a partial query.
+type completionState struct {
+	expect expectation
+
+	// property is the property before an operator or a value.
+	property string
+
+	// field is the property of property:expr which the end is the expr of.
+	field string
+
+	// parenField is the property of property:(...) of the innermost open parenthesis, empty if not of
+	// property:(...).
+	parenField string
+
+	open bool
+}
+
+// newCompletionState follows the grammar over s in partial mode.
+func newCompletionState(s string) *completionState {
+	segments := parsePartial(s)
+	seg := &segments[len(segments)-1]
+	end := len(s)
+
+	st := &completionState{expect: expectAfterExpr}
+	switch {
+	case len(seg.at(end, ruleExpectValue)) > 0:
+		st.expect = expectValue
+	case len(seg.at(end, ruleExpectString)) > 0:
+		st.expect = expectString
+	case len(seg.at(end, ruleExpectOperator)) > 0:
+		st.expect = expectOperator
+	case len(seg.at(end, ruleExpectExpr)) > 0:
+		st.expect = expectExpr
+	}
+	for _, t := range seg.tokens {
+		if t.pegRule == ruleProperty && t.begin < t.end {
+			st.property = seg.text(s, t)
+		}
+	}
+	if st.expect == expectOperator && st.property == "NOT" {
+		st.expect = expectExpr
+	}
+
+	// expressions which end at the end, from the outermost
+	closes := len(seg.at(end, ruleExpectClose))
+	st.open = closes > 0
+	exprs := seg.at(end, ruleExpr)
+loop:
+	for i := len(exprs) - 1; i >= 0; i-- {
+		e := exprs[i]
+		if e.begin == e.end {
+			continue
+		}
+		if s[seg.offsets[e.begin]] == '(' {
+			if closes == 0 {
+				break
+			}
+			closes--
+			st.parenField = st.field
+			continue
+		}
+		var property string
+		colon, not := false, false
+		for _, t := range seg.tokens {
+			switch {
+			case t.begin == e.begin && t.pegRule == ruleProperty:
+				property = seg.text(s, t)
+			case t.begin == e.begin && t.pegRule == ruleNot:
+				not = true
+			case t.pegRule == ruleColon && t.begin > e.begin && t.end <= e.end:
+				colon = true
+			}
+		}
+		switch {
+		case property != "" && colon:
+			st.field = property
+		case !not:
+			break loop
+		}
+	}
+	return st
+}
+
+type completer struct {
+	schema *Schema
+
+	// prefix is the partial word before the cursor, unquoted if quoted.
+	prefix string
+
+	quoted bool
+
+	start, end int
+
+	suggestions []Suggestion
+}
+
+// add suggests text if it is completion of the prefix, match is compared with the prefix.
+func (c *completer) add(kind SuggestionKind, match, text string) {
+	if !strings.HasPrefix(strings.ToLower(match), strings.ToLower(c.prefix)) {
+		return
+	}
+	for _, v := range c.suggestions {
+		if v.Text == text {
+			return
+		}
+	}
+	c.suggestions = append(c.suggestions, Suggestion{Kind: kind, Text: text, Start: c.start, End: c.end})
+}
+
+func (c *completer) expr(st *completionState) {
+	if st.field != "" {
+		c.values(st.field)
+	} else if c.schema != nil && !c.quoted {
+		for _, f := range c.schema.Fields {
+			c.add(SuggestProperty, f.Name, f.Name)
+		}
+	}
+	c.keywords(false, st)
+}
+
+func (c *completer) afterExpr(st *completionState) {
+	c.keywords(true, st)
+	if st.parenField == "" && c.schema != nil && !c.quoted {
+		for _, f := range c.schema.Fields {
+			c.add(SuggestProperty, f.Name, f.Name)
+		}
+	}
+}
+
+func (c *completer) keywords(boolean bool, st *completionState) {
+	if c.quoted {
+		return
+	}
+	if boolean {
+		c.add(SuggestKeyword, "AND", "AND")
+		c.add(SuggestKeyword, "OR", "OR")
+	}
+	c.add(SuggestKeyword, "NOT", "NOT")
+	if c.prefix != "" {
+		return
+	}
+	c.add(SuggestParen, "(", "(")
+	if boolean && st.open {
+		c.add(SuggestParen, ")", ")")
+	}
+}
+
+func (c *completer) operators(property string) {
+	var f *Field
+	if c.schema != nil {
+		var ok bool
+		if f, ok = c.schema.Field(property); !ok {
+			return
+		}
+	}
+	c.add(SuggestOperator, ":", ":")
+	if f == nil {
+		for _, op := range allOperators[1:] {
+			c.add(SuggestOperator, op, op)
+		}
+		return
+	}
+	for _, op := range f.Operators() {
+		c.add(SuggestOperator, op.String(), op.String())
+	}
+}
+
+func (c *completer) values(property string) {
+	if c.schema == nil {
+		return
+	}
+	f, ok := c.schema.Field(property)
+	if !ok {
+		return
+	}
+	if len(f.Values) == 0 && f.Kind == ast.KindBool && !c.quoted {
+		c.add(SuggestValue, "true", "true")
+		c.add(SuggestValue, "false", "false")
+		return
+	}
+	for _, v := range f.Values {
+		text := quoteString(v)
+		if c.quoted {
+			text = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+		}
+		c.add(SuggestValue, v, text)
+	}
+}
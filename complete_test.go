@@ -0,0 +1,107 @@
+package searchquery
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	schema := &Schema{Fields: []Field{
+		{Name: "genre", Kind: ast.KindString, Values: []string{"rock", "jazz", "hip hop"}},
+		{Name: "price", Kind: ast.KindFloat},
+		{Name: "published", Kind: ast.KindTime},
+		{Name: "used", Kind: ast.KindBool},
+	}}
+	// | is the cursor
+	cases := []struct {
+		Input    string
+		Schema   *Schema
+		Expected []string
+	}{
+		{`|`, schema, []string{"genre", "price", "published", "used", "NOT", "("}},
+		{`p|`, schema, []string{"price", "published"}},
+		{`N|`, schema, []string{"NOT"}},
+		{`price |`, schema, []string{":", "=", "!=", "<", "<=", ">", ">=", "AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`used |`, schema, []string{":", "=", "!=", "AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`blue |`, schema, []string{"AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`price A|`, schema, []string{"AND"}},
+		{`price <|`, schema, []string{"<", "<="}},
+		{`price < |`, schema, nil},
+		{`price !|`, schema, []string{"!="}},
+		{`genre <|`, schema, nil},
+		{`genre !|`, schema, []string{"!="}},
+		{`genre = |`, schema, []string{"rock", "jazz", `"hip hop"`}},
+		{`genre=j|`, schema, []string{"jazz"}},
+		{`genre:"h|`, schema, []string{`"hip hop"`}},
+		{`used != |`, schema, []string{"true", "false"}},
+		{`genre:|`, schema, []string{"rock", "jazz", `"hip hop"`, "NOT", "("}},
+		{`genre:NOT |`, schema, []string{"rock", "jazz", `"hip hop"`, "NOT", "("}},
+		{`genre:~|`, schema, []string{"rock", "jazz", `"hip hop"`}},
+		{`NOT |`, schema, []string{"genre", "price", "published", "used", "NOT", "("}},
+		{`price AND|`, schema, []string{"AND"}},
+		{`genre:"rock" |`, schema, []string{"AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`genre:(rock OR |`, schema, []string{"rock", "jazz", `"hip hop"`, "NOT", "("}},
+		{`genre:(rock |`, schema, []string{"AND", "OR", "NOT", "(", ")"}},
+		{`genre:(rock) |`, schema, []string{"AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`(blue OR price > 10 |`, schema, []string{"AND", "OR", "NOT", "(", ")", "genre", "price", "published", "used"}},
+		{`published >= 2020-01-01T10:00 |`, schema, []string{"AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`"unterminated |`, schema, nil},
+		{`title |`, schema, []string{"AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`title |`, nil, []string{":", "=", "!=", "<", "<=", ">", ">=", "AND", "OR", "NOT", "("}},
+		{`|`, nil, []string{"NOT", "("}},
+		{"# comment\nprice |", schema, []string{":", "=", "!=", "<", "<=", ">", ">=", "AND", "OR", "NOT", "(", "genre", "price", "published", "used"}},
+		{`pri|ce < 10`, schema, []string{"price"}},
+	}
+	for _, c := range cases {
+		pos := strings.IndexByte(c.Input, '|')
+		input := c.Input[:pos] + c.Input[pos+1:]
+		var actual []string
+		for _, v := range Complete(input, pos, c.Schema) {
+			actual = append(actual, v.Text)
+		}
+		assert.Equal(t, c.Expected, actual, c.Input)
+	}
+}
+
+func TestComplete_Span(t *testing.T) {
+	schema := &Schema{Fields: []Field{
+		{Name: "genre", Kind: ast.KindString, Values: []string{"rock", "hip hop"}},
+		{Name: "price", Kind: ast.KindFloat},
+	}}
+	assert.Equal(t, []Suggestion{
+		{Kind: SuggestProperty, Text: "genre", Start: 5, End: 7},
+	}, Complete("blue ge", 7, schema))
+	assert.Equal(t, []Suggestion{
+		{Kind: SuggestValue, Text: `"hip hop"`, Start: 6, End: 8},
+	}, Complete(`genre:"h`, 8, schema))
+	assert.Equal(t, []Suggestion{
+		{Kind: SuggestValue, Text: "rock", Start: 8, End: 8},
+		{Kind: SuggestValue, Text: `"hip hop"`, Start: 8, End: 8},
+	}, Complete(`genre = `, 100, schema))
+	assert.Equal(t, []Suggestion{
+		{Kind: SuggestOperator, Text: "<", Start: 6, End: 7},
+		{Kind: SuggestOperator, Text: "<=", Start: 6, End: 7},
+	}, Complete(`price < 5`, 7, schema))
+	assert.Nil(t, Complete(`genre < 5`, 7, schema))
+	assert.Equal(t, []Suggestion{
+		{Kind: SuggestValue, Text: `"ジャズ"`, Start: 6, End: 9},
+	}, Complete(`genre:ジ`, 9, &Schema{Fields: []Field{{Name: "genre", Values: []string{"ジャズ", "rock"}}}}))
+}
+
+func TestComplete_Limit(t *testing.T) {
+	s := strings.Repeat("a é ", 4000)
+	start := time.Now()
+	assert.Nil(t, Complete(s, len(s), nil))
+	assert.NotNil(t, Complete(s, len(s), nil, WithMaxInputBytes(len(s))))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	s = strings.Repeat("é ", 500)
+	assert.Equal(t, []Suggestion{
+		{Kind: SuggestKeyword, Text: "NOT", Start: 1500, End: 1500},
+		{Kind: SuggestParen, Text: "(", Start: 1500, End: 1500},
+	}, Complete(s, len(s), nil))
+}
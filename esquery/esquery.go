@@ -0,0 +1,208 @@
+// Package esquery translates query expressions into the Elasticsearch / OpenSearch query DSL.
+//
+// The result is a JSON object such as
+//
+//	{"bool": {"must": [{"match": {"model": "gibson"}}, {"range": {"date": {"lt": "1965-01-01T00:00:00Z"}}}]}}
+//
+// which can be used as "query" of a search request.
+package esquery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/esquery"
+)
+
+type Translator struct {
+	// DefaultFields are the fields searched by keywords outside of property:expr, the index's
+	// index.query.default_field is used if empty.
+	DefaultFields []string
+
+	// TimeFormat formats time values, time.RFC3339 by default.
+	TimeFormat string
+
+	// StemAnalyzer is the search analyzer of stemmed keywords such as ~dog, e.g. "english", so that they match
+	// fields indexed by the stemming analyzer. Stemmed keywords are an error if empty.
+	StemAnalyzer string
+}
+
+// Translate returns the query DSL for expr.
+func (t *Translator) Translate(expr ast.Expr) (map[string]interface{}, error) {
+	return t.expr(expr, "")
+}
+
+// expr translates expr, field is non-empty inside of property:expr.
+func (t *Translator) expr(expr ast.Expr, field string) (map[string]interface{}, error) {
+	switch e := expr.(type) {
+	case ast.And:
+		if len(e) == 0 {
+			return nil, fmt.Errorf("%s: empty and expression", pkgName)
+		}
+		var must, mustNot []interface{}
+		for _, v := range e {
+			if not, ok := v.(*ast.Not); ok {
+				q, err := t.expr(not.Expr, field)
+				if err != nil {
+					return nil, err
+				}
+				mustNot = append(mustNot, q)
+				continue
+			}
+			q, err := t.expr(v, field)
+			if err != nil {
+				return nil, err
+			}
+			must = append(must, q)
+		}
+		b := map[string]interface{}{}
+		if len(must) > 0 {
+			b["must"] = must
+		}
+		if len(mustNot) > 0 {
+			b["must_not"] = mustNot
+		}
+		return boolQuery(b), nil
+	case ast.Or:
+		if len(e) == 0 {
+			return nil, fmt.Errorf("%s: empty or expression", pkgName)
+		}
+		var should []interface{}
+		for _, v := range e {
+			q, err := t.expr(v, field)
+			if err != nil {
+				return nil, err
+			}
+			should = append(should, q)
+		}
+		return boolQuery(map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		}), nil
+	case *ast.Not:
+		q, err := t.expr(e.Expr, field)
+		if err != nil {
+			return nil, err
+		}
+		return boolQuery(map[string]interface{}{
+			"must_not": []interface{}{q},
+		}), nil
+	case *ast.OperatorExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		if e.Value == nil {
+			return nil, fmt.Errorf("%s: property %q: nil value", pkgName, e.Property)
+		}
+		v := t.value(e.Value)
+		switch e.Operator {
+		case ast.OpEq:
+			return term(e.Property, v), nil
+		case ast.OpNeq:
+			return boolQuery(map[string]interface{}{
+				"must_not": []interface{}{term(e.Property, v)},
+			}), nil
+		case ast.OpLt, ast.OpLe, ast.OpGt, ast.OpGe:
+			return map[string]interface{}{
+				"range": map[string]interface{}{
+					e.Property: map[string]interface{}{
+						rangeOperators[e.Operator]: v,
+					},
+				},
+			}, nil
+		default:
+			return nil, fmt.Errorf("%s: invalid operator %v", pkgName, e.Operator)
+		}
+	case *ast.ColonExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		return t.expr(e.Expr, e.Property)
+	case *ast.KeywordExpr:
+		s, err := ast.AsString(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		if e.Stem && t.StemAnalyzer == "" {
+			return nil, fmt.Errorf("%s: stemmed keyword ~%q needs StemAnalyzer", pkgName, s)
+		}
+		if field != "" {
+			typ := "match"
+			if isPhrase(s) {
+				typ = "match_phrase"
+			}
+			var q interface{} = s
+			if e.Stem {
+				q = map[string]interface{}{
+					"query":    s,
+					"analyzer": t.StemAnalyzer,
+				}
+			}
+			return map[string]interface{}{
+				typ: map[string]interface{}{
+					field: q,
+				},
+			}, nil
+		}
+		mm := map[string]interface{}{
+			"query": s,
+		}
+		if e.Stem {
+			mm["analyzer"] = t.StemAnalyzer
+		}
+		if len(t.DefaultFields) > 0 {
+			mm["fields"] = t.DefaultFields
+		}
+		if isPhrase(s) {
+			mm["type"] = "phrase"
+		}
+		return map[string]interface{}{
+			"multi_match": mm,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+}
+
+var rangeOperators = map[ast.Op]string{
+	ast.OpLt: "lt",
+	ast.OpLe: "lte",
+	ast.OpGt: "gt",
+	ast.OpGe: "gte",
+}
+
+func (t *Translator) value(v ast.Value) interface{} {
+	if tv, ok := v.(ast.TimeValue); ok {
+		layout := t.TimeFormat
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return time.Time(tv).Format(layout)
+	}
+	return v.Raw()
+}
+
+func boolQuery(b map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": b,
+	}
+}
+
+func term(field string, v interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"term": map[string]interface{}{
+			field: v,
+		},
+	}
+}
+
+// isPhrase reports whether s consists of several words, as a quoted string in a query.
+func isPhrase(s string) bool {
+	return strings.IndexFunc(strings.TrimSpace(s), unicode.IsSpace) >= 0
+}
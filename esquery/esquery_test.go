@@ -0,0 +1,75 @@
+package esquery
+
+import (
+	"encoding/json"
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	tr := &Translator{
+		DefaultFields: []string{"title", "body"},
+		StemAnalyzer:  "english",
+	}
+	cases := []struct {
+		Query    string
+		Expected string
+	}{
+		{
+			`model:gibson date < 1965-01-01`,
+			`{"bool":{"must":[{"match":{"model":"gibson"}},{"range":{"date":{"lt":"1965-01-01T00:00:00Z"}}}]}}`,
+		},
+		{
+			`title:"Harry Potter" AND pages >= 500`,
+			`{"bool":{"must":[{"match_phrase":{"title":"Harry Potter"}},{"range":{"pages":{"gte":500}}}]}}`,
+		},
+		{
+			`beverage:wine color:(red OR white) NOT country:france`,
+			`{"bool":{"must":[{"match":{"beverage":"wine"}},{"bool":{"minimum_should_match":1,"should":[{"match":{"color":"red"}},{"match":{"color":"white"}}]}}],"must_not":[{"match":{"country":"france"}}]}}`,
+		},
+		{
+			`NOT users.type != dogs`,
+			`{"bool":{"must_not":[{"bool":{"must_not":[{"term":{"users.type":"dogs"}}]}}]}}`,
+		},
+		{
+			`"blue guitar" OR available = true`,
+			`{"bool":{"minimum_should_match":1,"should":[{"multi_match":{"fields":["title","body"],"query":"blue guitar","type":"phrase"}},{"term":{"available":true}}]}}`,
+		},
+		{
+			`~dogs title:~"running dogs"`,
+			`{"bool":{"must":[{"multi_match":{"fields":["title","body"],"query":"dogs","analyzer":"english"}},{"match_phrase":{"title":{"query":"running dogs","analyzer":"english"}}}]}}`,
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		q, err := tr.Translate(expr)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		b, err := json.Marshal(q)
+		if assert.NoError(t, err, c.Query) {
+			assert.JSONEq(t, c.Expected, string(b), c.Query)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			ast.And{},
+			ast.Or{},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+			&ast.ColonExpr{Property: "", Expr: &ast.KeywordExpr{Value: ast.StringValue("x")}},
+		} {
+			_, err := tr.Translate(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+
+		_, err := (&Translator{}).Translate(&ast.KeywordExpr{Value: ast.StringValue("dogs"), Stem: true})
+		assert.Error(t, err)
+	})
+}
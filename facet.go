@@ -0,0 +1,209 @@
+package searchquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// FacetRefinement narrows search results to a value or a range of a facet, e.g. "genre:rock" or
+// "price:[10, 20)".
+type FacetRefinement struct {
+	Name string
+
+	// Value is the value of the facet, nil if Range is set.
+	Value ast.Value
+
+	Range *FacetRange
+}
+
+// FacetRange is the range [Start, End) of numbers, nil bounds are open.
+type FacetRange struct {
+	Start ast.Value
+
+	End ast.Value
+}
+
+// ParseFacetRefinement parses a refinement token of the form name:value or name:[start, end), where value
+// is a number, a bare word or a quoted string, and either bound of a range can be empty.
+func ParseFacetRefinement(s string) (*FacetRefinement, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil, fmt.Errorf("%s: facet refinement %q: missing ':'", pkgName, s)
+	}
+	name, rest := s[:i], strings.TrimSpace(s[i+1:])
+	if !propertyPattern.MatchString(name) {
+		return nil, fmt.Errorf("%s: facet refinement %q: invalid name", pkgName, s)
+	}
+	if rest == "" {
+		return nil, fmt.Errorf("%s: facet refinement %q: missing value", pkgName, s)
+	}
+
+	if rest[0] != '[' {
+		v, err := parseFacetValue(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%s: facet refinement %q: %v", pkgName, s, err)
+		}
+		return &FacetRefinement{Name: name, Value: v}, nil
+	}
+	if !strings.HasSuffix(rest, ")") {
+		return nil, fmt.Errorf("%s: facet refinement %q: range must end with ')'", pkgName, s)
+	}
+	bounds := strings.Split(rest[1:len(rest)-1], ",")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("%s: facet refinement %q: range must have start and end", pkgName, s)
+	}
+	var r FacetRange
+	for i, bound := range bounds {
+		bound = strings.TrimSpace(bound)
+		if bound == "" {
+			continue
+		}
+		v, err := parseFacetNumber(bound)
+		if err != nil {
+			return nil, fmt.Errorf("%s: facet refinement %q: %v", pkgName, s, err)
+		}
+		if i == 0 {
+			r.Start = v
+		} else {
+			r.End = v
+		}
+	}
+	if r.Start == nil && r.End == nil {
+		return nil, fmt.Errorf("%s: facet refinement %q: range must have start or end", pkgName, s)
+	}
+	return &FacetRefinement{Name: name, Range: &r}, nil
+}
+
+func parseFacetValue(s string) (ast.Value, error) {
+	if strings.HasPrefix(s, `"`) {
+		if len(s) < 2 || !strings.HasSuffix(s, `"`) {
+			return nil, fmt.Errorf("unterminated quoted string")
+		}
+		var b strings.Builder
+		q := s[1 : len(s)-1]
+		for i := 0; i < len(q); i++ {
+			if q[i] == '\\' && i+1 < len(q) {
+				i++
+			} else if q[i] == '"' {
+				return nil, fmt.Errorf("unescaped '\"' in quoted string")
+			}
+			b.WriteByte(q[i])
+		}
+		return ast.StringValue(b.String()), nil
+	}
+	if v, err := parseFacetNumber(s); err == nil {
+		return v, nil
+	}
+	return ast.StringValue(s), nil
+}
+
+func parseFacetNumber(s string) (ast.Value, error) {
+	if !numberPattern.MatchString(s) {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ast.IntegerValue(i), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return ast.FloatValue(f), nil
+}
+
+// String returns the token of r, which ParseFacetRefinement reads back.
+func (r *FacetRefinement) String() string {
+	var b strings.Builder
+	b.WriteString(r.Name + ":")
+	if r.Range != nil {
+		b.WriteString("[")
+		if r.Range.Start != nil {
+			s, _ := ast.AsString(r.Range.Start)
+			b.WriteString(s)
+		}
+		b.WriteString(", ")
+		if r.Range.End != nil {
+			s, _ := ast.AsString(r.Range.End)
+			b.WriteString(s)
+		}
+		b.WriteString(")")
+		return b.String()
+	}
+	s, _ := ast.AsString(r.Value)
+	if _, ok := r.Value.(ast.StringValue); ok {
+		s = quoteString(s)
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// Expr returns the condition of r, an equality of the value or comparisons with the bounds of the range.
+func (r *FacetRefinement) Expr() (ast.Expr, error) {
+	if !propertyPattern.MatchString(r.Name) {
+		return nil, fmt.Errorf("%s: facet refinement: invalid name %q", pkgName, r.Name)
+	}
+	if r.Range == nil {
+		if r.Value == nil {
+			return nil, fmt.Errorf("%s: facet refinement %s: no value nor range", pkgName, r.Name)
+		}
+		return &ast.OperatorExpr{Property: r.Name, Operator: ast.OpEq, Value: r.Value}, nil
+	}
+	var and ast.And
+	if r.Range.Start != nil {
+		and = append(and, &ast.OperatorExpr{Property: r.Name, Operator: ast.OpGe, Value: r.Range.Start})
+	}
+	if r.Range.End != nil {
+		and = append(and, &ast.OperatorExpr{Property: r.Name, Operator: ast.OpLt, Value: r.Range.End})
+	}
+	switch len(and) {
+	case 0:
+		return nil, fmt.Errorf("%s: facet refinement %s: range without bounds", pkgName, r.Name)
+	case 1:
+		return and[0], nil
+	default:
+		return and, nil
+	}
+}
+
+// ApplyRefinements returns expr narrowed by refinements as extra And clauses, expr may be nil to refine all
+// documents. As GAE does, refinements of the same facet are combined by OR, and of different facets by AND.
+func ApplyRefinements(expr ast.Expr, refinements []FacetRefinement) (ast.Expr, error) {
+	var (
+		names  []string
+		byName = map[string][]ast.Expr{}
+	)
+	for i := range refinements {
+		e, err := refinements[i].Expr()
+		if err != nil {
+			return nil, err
+		}
+		name := refinements[i].Name
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], e)
+	}
+
+	var and ast.And
+	if expr != nil {
+		and = append(and, expr)
+	}
+	for _, name := range names {
+		if exprs := byName[name]; len(exprs) == 1 {
+			and = append(and, exprs[0])
+		} else {
+			and = append(and, ast.Or(exprs))
+		}
+	}
+	switch len(and) {
+	case 0:
+		return nil, fmt.Errorf("%s: no query nor refinements", pkgName)
+	case 1:
+		return and[0], nil
+	default:
+		return and, nil
+	}
+}
@@ -0,0 +1,99 @@
+package searchquery
+
+import (
+	"testing"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFacetRefinement(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected *FacetRefinement
+		String   string
+	}{
+		{`genre:rock`, &FacetRefinement{Name: "genre", Value: ast.StringValue("rock")}, `genre:rock`},
+		{`genre:"hard rock"`, &FacetRefinement{Name: "genre", Value: ast.StringValue("hard rock")}, `genre:"hard rock"`},
+		{`genre:"10"`, &FacetRefinement{Name: "genre", Value: ast.StringValue("10")}, `genre:"10"`},
+		{`year:1997`, &FacetRefinement{Name: "year", Value: ast.IntegerValue(1997)}, `year:1997`},
+		{`price:[10, 20.5)`, &FacetRefinement{Name: "price", Range: &FacetRange{Start: ast.IntegerValue(10), End: ast.FloatValue(20.5)}}, `price:[10, 20.5)`},
+		{`price:[,20)`, &FacetRefinement{Name: "price", Range: &FacetRange{End: ast.IntegerValue(20)}}, `price:[, 20)`},
+		{`price:[-1.5,)`, &FacetRefinement{Name: "price", Range: &FacetRange{Start: ast.FloatValue(-1.5)}}, `price:[-1.5, )`},
+		{`genre:Infinity`, &FacetRefinement{Name: "genre", Value: ast.StringValue("Infinity")}, `genre:Infinity`},
+		{`genre:nan`, &FacetRefinement{Name: "genre", Value: ast.StringValue("nan")}, `genre:nan`},
+		{`code:1e3`, &FacetRefinement{Name: "code", Value: ast.StringValue("1e3")}, `code:"1e3"`},
+	}
+	for _, c := range cases {
+		r, err := ParseFacetRefinement(c.Input)
+		if !assert.NoError(t, err, c.Input) {
+			continue
+		}
+		assert.Equal(t, c.Expected, r, c.Input)
+		assert.Equal(t, c.String, r.String(), c.Input)
+		back, err := ParseFacetRefinement(r.String())
+		if assert.NoError(t, err, c.Input) {
+			assert.Equal(t, r, back, c.Input)
+		}
+	}
+
+	for _, s := range []string{
+		``,
+		`genre`,
+		`1genre:rock`,
+		`genre:`,
+		`genre:"rock`,
+		`price:[1, 2]`,
+		`price:[1)`,
+		`price:[,)`,
+		`price:[a, 2)`,
+		`price:[nan, inf)`,
+		`price:[0x10, 20)`,
+	} {
+		_, err := ParseFacetRefinement(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestApplyRefinements(t *testing.T) {
+	var refinements []FacetRefinement
+	for _, s := range []string{`genre:rock`, `price:[10, 20)`, `genre:jazz`, `year:[, 2000)`} {
+		r, err := ParseFacetRefinement(s)
+		if !assert.NoError(t, err, s) {
+			return
+		}
+		refinements = append(refinements, *r)
+	}
+	expr, err := Parse(`music`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	refined, err := ApplyRefinements(expr, refinements)
+	if assert.NoError(t, err) {
+		s, err := Format(refined)
+		if assert.NoError(t, err) {
+			assert.Equal(t, `music AND (genre = rock OR genre = jazz) AND (price >= 10 AND price < 20) AND year < 2000`, s)
+		}
+	}
+
+	refined, err = ApplyRefinements(nil, refinements[:1])
+	if assert.NoError(t, err) {
+		assert.Equal(t, &ast.OperatorExpr{Property: "genre", Operator: ast.OpEq, Value: ast.StringValue("rock")}, refined)
+	}
+
+	r, err := ParseFacetRefinement(`genre:Infinity`)
+	if assert.NoError(t, err) {
+		refined, err = ApplyRefinements(nil, []FacetRefinement{*r})
+		if assert.NoError(t, err) {
+			s, err := Format(refined)
+			if assert.NoError(t, err) {
+				assert.Equal(t, `genre = Infinity`, s)
+			}
+		}
+	}
+
+	_, err = ApplyRefinements(nil, nil)
+	assert.Error(t, err)
+	_, err = ApplyRefinements(expr, []FacetRefinement{{Name: "genre"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,19 @@
+package searchquery
+
+import (
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/kamichidu/go-gae-search-query/internal/scalarexpr"
+)
+
+// ParseFieldExpr parses a field expression, which computes a field returned with search results, e.g.
+// `snippet("rose", content, 100)`, "count(tags)" or "price * qty".
+func ParseFieldExpr(s string) (ast.Scalar, error) {
+	expr, err := scalarexpr.ParseField(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScalar(expr, true); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
@@ -0,0 +1,77 @@
+package searchquery
+
+import (
+	"testing"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldExpr(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected ast.Scalar
+	}{
+		{
+			`snippet("rose", content, 100)`,
+			&ast.Call{Func: "snippet", Args: []ast.Scalar{
+				&ast.Literal{Value: ast.StringValue("rose")},
+				&ast.FieldRef{Name: "content"},
+				&ast.Literal{Value: ast.IntegerValue(100)},
+			}},
+		},
+		{
+			`count(tags)`,
+			&ast.Call{Func: "count", Args: []ast.Scalar{&ast.FieldRef{Name: "tags"}}},
+		},
+		{
+			`price * qty`,
+			&ast.BinaryExpr{Op: ast.ArithMul, X: &ast.FieldRef{Name: "price"}, Y: &ast.FieldRef{Name: "qty"}},
+		},
+		{
+			`-price`,
+			&ast.Neg{X: &ast.FieldRef{Name: "price"}},
+		},
+		{
+			`-1.5`,
+			&ast.Literal{Value: ast.FloatValue(-1.5)},
+		},
+		{
+			`-a + b`,
+			&ast.BinaryExpr{Op: ast.ArithAdd, X: &ast.Neg{X: &ast.FieldRef{Name: "a"}}, Y: &ast.FieldRef{Name: "b"}},
+		},
+		{
+			`-a * b`,
+			&ast.BinaryExpr{Op: ast.ArithMul, X: &ast.Neg{X: &ast.FieldRef{Name: "a"}}, Y: &ast.FieldRef{Name: "b"}},
+		},
+		{
+			`-2 - 3`,
+			&ast.BinaryExpr{Op: ast.ArithSub, X: &ast.Literal{Value: ast.IntegerValue(-2)}, Y: &ast.Literal{Value: ast.IntegerValue(3)}},
+		},
+		{
+			`-(a + b)`,
+			&ast.Neg{X: &ast.BinaryExpr{Op: ast.ArithAdd, X: &ast.FieldRef{Name: "a"}, Y: &ast.FieldRef{Name: "b"}}},
+		},
+	}
+	for _, c := range cases {
+		expr, err := ParseFieldExpr(c.Input)
+		if assert.NoError(t, err, c.Input) {
+			assert.Equal(t, c.Expected, expr, c.Input)
+		}
+	}
+
+	for _, s := range []string{
+		``,
+		`price DEFAULT 0`,
+		`+price`,
+		`n = 99999999999999999999`,
+		`99999999999999999999`,
+		`2020-13-45`,
+		`snippet("rose")`,
+		`snippet("rose", "content")`,
+		`nosuch(a)`,
+	} {
+		_, err := ParseFieldExpr(s)
+		assert.Error(t, err, s)
+	}
+}
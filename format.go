@@ -0,0 +1,193 @@
+package searchquery
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+var (
+	propertyPattern = regexp.MustCompile(`^[a-zA-Z][_a-zA-Z0-9]*(\.[a-zA-Z][_a-zA-Z0-9]*)*$`)
+
+	bareStringPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+	// numbers of the grammar, e.g. not "1e3", "inf" or "NaN"
+	numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+	// the grammar matches these words as prefixes, so any bare word starting with one of them
+	// must be quoted.
+	reservedPrefixes = []string{"AND", "OR", "NOT", "true", "false"}
+)
+
+// Format prints expr as a query string which Parse reads back into an equivalent expression.
+func Format(expr ast.Expr) (string, error) {
+	var b strings.Builder
+	if err := formatExpr(&b, expr); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func formatExpr(b *strings.Builder, expr ast.Expr) error {
+	switch e := expr.(type) {
+	case ast.And:
+		if len(e) == 0 {
+			return fmt.Errorf("%s: empty and expression", pkgName)
+		}
+		for i, v := range e {
+			if i > 0 {
+				b.WriteString(" AND ")
+			}
+			if err := formatOperand(b, v, isCompound(v)); err != nil {
+				return err
+			}
+		}
+	case ast.Or:
+		if len(e) == 0 {
+			return fmt.Errorf("%s: empty or expression", pkgName)
+		}
+		for i, v := range e {
+			if i > 0 {
+				b.WriteString(" OR ")
+			}
+			// "a OR b OR c" parses as ((a OR b) OR c), so only the leading Or can go without parens
+			_, isOr := v.(ast.Or)
+			paren := isCompound(v) && !(i == 0 && isOr)
+			if err := formatOperand(b, v, paren); err != nil {
+				return err
+			}
+		}
+	case *ast.Not:
+		b.WriteString("NOT ")
+		if err := formatOperand(b, e.Expr, isCompound(e.Expr)); err != nil {
+			return err
+		}
+	case *ast.OperatorExpr:
+		if err := formatProperty(b, e.Property); err != nil {
+			return err
+		}
+		b.WriteString(" ")
+		b.WriteString(e.Operator.String())
+		b.WriteString(" ")
+		return formatValue(b, e.Value)
+	case *ast.ColonExpr:
+		if err := formatProperty(b, e.Property); err != nil {
+			return err
+		}
+		b.WriteString(":")
+		return formatOperand(b, e.Expr, isCompound(e.Expr))
+	case *ast.KeywordExpr:
+		if e.Stem {
+			b.WriteString("~")
+		}
+		return formatValue(b, e.Value)
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func isCompound(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case ast.And:
+		return len(e) > 1
+	case ast.Or:
+		return len(e) > 1
+	default:
+		return false
+	}
+}
+
+func formatOperand(b *strings.Builder, expr ast.Expr, paren bool) error {
+	if !paren {
+		return formatExpr(b, expr)
+	}
+	b.WriteString("(")
+	if err := formatExpr(b, expr); err != nil {
+		return err
+	}
+	b.WriteString(")")
+	return nil
+}
+
+func formatProperty(b *strings.Builder, s string) error {
+	if !propertyPattern.MatchString(s) {
+		return fmt.Errorf("%s: invalid property name %q", pkgName, s)
+	}
+	b.WriteString(s)
+	return nil
+}
+
+func formatValue(b *strings.Builder, v ast.Value) error {
+	switch v := v.(type) {
+	case ast.StringValue:
+		b.WriteString(quoteString(string(v)))
+	case ast.IntegerValue:
+		b.WriteString(strconv.FormatInt(int64(v), 10))
+	case ast.FloatValue:
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("%s: unrepresentable float value %v", pkgName, f)
+		}
+		s := strconv.FormatFloat(f, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		b.WriteString(s)
+	case ast.BoolValue:
+		b.WriteString(strconv.FormatBool(bool(v)))
+	case ast.TimeValue:
+		s, err := formatTime(time.Time(v))
+		if err != nil {
+			return err
+		}
+		b.WriteString(s)
+	default:
+		return fmt.Errorf("%s: unknown value type %T", pkgName, v)
+	}
+	return nil
+}
+
+func formatTime(t time.Time) (string, error) {
+	t = t.UTC()
+	if t.Year() < 1000 || t.Year() > 9999 {
+		return "", fmt.Errorf("%s: unrepresentable time value %v: year out of range", pkgName, t)
+	}
+	if t.Nanosecond() != 0 {
+		return "", fmt.Errorf("%s: unrepresentable time value %v: sub-second precision", pkgName, t)
+	}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02"), nil
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+func quoteString(s string) string {
+	if bareStringPattern.MatchString(s) && !hasReservedPrefix(s) {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func hasReservedPrefix(s string) bool {
+	for _, prefix := range reservedPrefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
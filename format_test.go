@@ -0,0 +1,76 @@
+package searchquery
+
+import (
+	"testing"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, s := range []string{
+			`blue`,
+			`NOT white`,
+			`blue OR red`,
+			`blue AND guitar`,
+			`model:gibson AND date < 1965-01-01`,
+			`title:"Harry Potter" AND pages < 500`,
+			`beverage:wine AND color:(red OR white) AND NOT country:france`,
+			`true AND false`,
+			`NOT cat AND (dogs OR horses)`,
+			`NOT cat OR dogs OR horses`,
+			`cat OR (dogs AND horses) OR (birds OR fish)`,
+			`users.user_id = xxx`,
+			`created >= 2006-01-02T15:04:05Z`,
+			`price < 0.5 AND stock != 0 AND diff > -3 AND temp <= -1.25`,
+			`"say \"hello\"" AND "back\\slash"`,
+			`"NOTE" AND "ORange" AND "trueish" AND "1984" AND ""`,
+			`~dog AND title:~"running shoes"`,
+			`"東京都" AND "Café"`,
+		} {
+			expr, err := Parse(s)
+			if !assert.NoError(t, err, s) {
+				continue
+			}
+			out, err := Format(expr)
+			if !assert.NoError(t, err, s) {
+				continue
+			}
+			assert.Equal(t, s, out)
+			reparsed, err := Parse(out)
+			if !assert.NoError(t, err, out) {
+				continue
+			}
+			assert.Equal(t, expr, reparsed, out)
+		}
+	})
+	t.Run("quoting", func(t *testing.T) {
+		expr := ast.And{
+			&ast.KeywordExpr{Value: ast.StringValue(`a" OR x = "b`)},
+			&ast.OperatorExpr{Property: "name", Operator: ast.OpEq, Value: ast.StringValue("x) OR (y")},
+		}
+		s, err := Format(expr)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, `"a\" OR x = \"b" AND name = "x) OR (y"`, s)
+		reparsed, err := Parse(s)
+		if !assert.NoError(t, err, s) {
+			return
+		}
+		assert.Equal(t, expr, reparsed)
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			ast.And{},
+			ast.Or{},
+			&ast.OperatorExpr{Property: "a b", Operator: ast.OpEq, Value: ast.IntegerValue(1)},
+			&ast.ColonExpr{Property: "", Expr: &ast.KeywordExpr{Value: ast.StringValue("x")}},
+			&ast.KeywordExpr{Value: nil},
+		} {
+			_, err := Format(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package searchquery
+
+import (
+	"html"
+	"strings"
+)
+
+// ANSIStyles are the SGR parameters which HighlightANSI colours tokens with, tokens of other kinds are not
+// coloured.
+var ANSIStyles = map[TokenKind]string{
+	TokenInvalid:      "1;31",
+	TokenProperty:     "36",
+	TokenOperator:     "33",
+	TokenColon:        "33",
+	TokenKeyword:      "1;35",
+	TokenQuotedString: "32",
+	TokenInteger:      "34",
+	TokenFloat:        "34",
+	TokenTime:         "34",
+	TokenBool:         "34",
+	TokenComment:      "2",
+}
+
+// HighlightANSI returns s coloured by ANSI escape sequences for terminals.
+func HighlightANSI(s string) string {
+	var b strings.Builder
+	for _, t := range Tokenize(s) {
+		style, ok := ANSIStyles[t.Kind]
+		if !ok {
+			b.WriteString(t.Text)
+			continue
+		}
+		b.WriteString("\x1b[" + style + "m" + t.Text + "\x1b[0m")
+	}
+	return b.String()
+}
+
+// HighlightHTML returns s escaped as HTML, with tokens but whitespace in spans of the class prefix followed
+// by the kind, e.g. <span class="q-property">title</span> for prefix "q-".
+func HighlightHTML(s, prefix string) string {
+	var b strings.Builder
+	for _, t := range Tokenize(s) {
+		if t.Kind == TokenWhitespace {
+			b.WriteString(html.EscapeString(t.Text))
+			continue
+		}
+		b.WriteString(`<span class="` + html.EscapeString(prefix+t.Kind.String()) + `">`)
+		b.WriteString(html.EscapeString(t.Text))
+		b.WriteString("</span>")
+	}
+	return b.String()
+}
@@ -0,0 +1,149 @@
+package scalarexpr
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+type builder struct {
+	Expr ast.Scalar
+
+	Descending bool
+
+	Default ast.Value
+
+	// field parses field expressions rather than sort expressions.
+	field bool
+
+	state list.List
+}
+
+// callStart marks the beginning of arguments of a function call.
+type callStart string
+
+func (b *builder) pushState(v interface{}) {
+	b.state.PushFront(v)
+}
+
+func (b *builder) popState() interface{} {
+	ele := b.state.Front()
+	if ele == nil {
+		return nil
+	}
+	b.state.Remove(ele)
+	return ele.Value
+}
+
+func (b *builder) popScalar() ast.Scalar {
+	v := b.popState()
+	expr, ok := v.(ast.Scalar)
+	if !ok {
+		panic(fmt.Sprintf("%s: invalid state: %T", pkgName, v))
+	}
+	return expr
+}
+
+func (b *builder) finalize() {
+	b.Expr = b.popScalar()
+	if n := b.state.Len(); n != 0 {
+		panic(fmt.Sprintf("%s: invalid state: remaining %d states", pkgName, n))
+	}
+}
+
+func (b *builder) setDescending() {
+	b.Descending = true
+}
+
+func (b *builder) setDefault() {
+	lit, ok := b.popScalar().(*ast.Literal)
+	if !ok {
+		panic(&Error{Message: "default value must be a literal"})
+	}
+	b.Default = lit.Value
+}
+
+func (b *builder) pushBinary(op ast.ArithOp) {
+	y := b.popScalar()
+	x := b.popScalar()
+	b.pushState(&ast.BinaryExpr{Op: op, X: x, Y: y})
+}
+
+func (b *builder) pushNeg() {
+	x := b.popScalar()
+	// fold negative numbers into literals
+	if lit, ok := x.(*ast.Literal); ok {
+		switch v := lit.Value.(type) {
+		case ast.IntegerValue:
+			b.pushState(&ast.Literal{Value: -v})
+			return
+		case ast.FloatValue:
+			b.pushState(&ast.Literal{Value: -v})
+			return
+		}
+	}
+	b.pushState(&ast.Neg{X: x})
+}
+
+func (b *builder) pushCallStart(name string) {
+	b.pushState(callStart(name))
+}
+
+func (b *builder) pushCall() {
+	var args []ast.Scalar
+	for {
+		v := b.popState()
+		if name, ok := v.(callStart); ok {
+			b.pushState(&ast.Call{Func: string(name), Args: args})
+			return
+		}
+		expr, ok := v.(ast.Scalar)
+		if !ok {
+			panic(fmt.Sprintf("%s: invalid state: %T", pkgName, v))
+		}
+		args = append([]ast.Scalar{expr}, args...)
+	}
+}
+
+func (b *builder) pushFieldRef(name string) {
+	b.pushState(&ast.FieldRef{Name: name})
+}
+
+func (b *builder) pushTimeValue(layout, s string) {
+	v, err := time.ParseInLocation(layout, s, time.UTC)
+	if err != nil {
+		panic(&Error{Message: err.Error()})
+	}
+	b.pushState(&ast.Literal{Value: ast.TimeValue(v)})
+}
+
+func (b *builder) pushQuotedStringValue(s string) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	b.pushState(&ast.Literal{Value: ast.StringValue(sb.String())})
+}
+
+func (b *builder) pushIntegerValue(s string) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		panic(&Error{Message: err.Error()})
+	}
+	b.pushState(&ast.Literal{Value: ast.IntegerValue(v)})
+}
+
+func (b *builder) pushFloatValue(s string) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic(&Error{Message: err.Error()})
+	}
+	b.pushState(&ast.Literal{Value: ast.FloatValue(v)})
+}
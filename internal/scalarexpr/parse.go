@@ -0,0 +1,71 @@
+// Package scalarexpr parses the expressions of sort and field expressions, which share a grammar.
+package scalarexpr
+
+import (
+	"fmt"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery"
+)
+
+type Result struct {
+	Expr ast.Scalar
+
+	// Descending is set by a leading "-".
+	Descending bool
+
+	// Default is set by a trailing "DEFAULT value".
+	Default ast.Value
+}
+
+// Error is an invalid expression which is syntactically well-formed.
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", pkgName, e.Message)
+}
+
+// Parse parses s as a sort expression.
+func Parse(s string) (*Result, error) {
+	return parse(s, false)
+}
+
+// ParseField parses s as a field expression, which has neither a direction nor a default value, so that a
+// leading "-" negates the first operand.
+func ParseField(s string) (ast.Scalar, error) {
+	res, err := parse(s, true)
+	if err != nil {
+		return nil, err
+	}
+	return res.Expr, nil
+}
+
+func parse(s string, field bool) (res *Result, err error) {
+	var p Scalar
+	p.Buffer = s
+	p.field = field
+	p.Init()
+	if err := p.Parse(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			serr, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			res, err = nil, serr
+		}
+	}()
+	p.Execute()
+	return &Result{
+		Expr:       p.Expr,
+		Descending: p.Descending,
+		Default:    p.Default,
+	}, nil
+}
@@ -0,0 +1,1836 @@
+package scalarexpr
+
+import (
+	"time"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const endSymbol rune = 1114112
+
+/* The rule types inferred from the grammar are below. */
+type pegRule uint8
+
+const (
+	ruleUnknown pegRule = iota
+	ruleStart
+	ruleDirection
+	ruleDefault
+	ruleExpr
+	ruleTerm
+	ruleFactor
+	ruleCall
+	ruleArgs
+	ruleFieldRef
+	ruleIdent
+	ruleIdentChar
+	ruleLiteral
+	ruleTime
+	ruleQuotedString
+	ruleInteger
+	ruleFloat
+	ruleSpacing
+	ruleComment
+	ruleSpace
+	ruleEndOfLine
+	ruleAction0
+	ruleAction1
+	ruleAction2
+	ruleAction3
+	ruleAction4
+	ruleAction5
+	ruleAction6
+	ruleAction7
+	rulePegText
+	ruleAction8
+	ruleAction9
+	ruleAction10
+	ruleAction11
+	ruleAction12
+	ruleAction13
+	ruleAction14
+	ruleAction15
+
+	rulePre
+	ruleIn
+	ruleSuf
+)
+
+var rul3s = [...]string{
+	"Unknown",
+	"Start",
+	"Direction",
+	"Default",
+	"Expr",
+	"Term",
+	"Factor",
+	"Call",
+	"Args",
+	"FieldRef",
+	"Ident",
+	"IdentChar",
+	"Literal",
+	"Time",
+	"QuotedString",
+	"Integer",
+	"Float",
+	"Spacing",
+	"Comment",
+	"Space",
+	"EndOfLine",
+	"Action0",
+	"Action1",
+	"Action2",
+	"Action3",
+	"Action4",
+	"Action5",
+	"Action6",
+	"Action7",
+	"PegText",
+	"Action8",
+	"Action9",
+	"Action10",
+	"Action11",
+	"Action12",
+	"Action13",
+	"Action14",
+	"Action15",
+
+	"Pre_",
+	"_In_",
+	"_Suf",
+}
+
+type node32 struct {
+	token32
+	up, next *node32
+}
+
+func (node *node32) print(depth int, buffer string) {
+	for node != nil {
+		for c := 0; c < depth; c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", rul3s[node.pegRule], strconv.Quote(string(([]rune(buffer)[node.begin:node.end]))))
+		if node.up != nil {
+			node.up.print(depth+1, buffer)
+		}
+		node = node.next
+	}
+}
+
+func (node *node32) Print(buffer string) {
+	node.print(0, buffer)
+}
+
+type element struct {
+	node *node32
+	down *element
+}
+
+/* ${@} bit structure for abstract syntax tree */
+type token32 struct {
+	pegRule
+	begin, end, next uint32
+}
+
+func (t *token32) isZero() bool {
+	return t.pegRule == ruleUnknown && t.begin == 0 && t.end == 0 && t.next == 0
+}
+
+func (t *token32) isParentOf(u token32) bool {
+	return t.begin <= u.begin && t.end >= u.end && t.next > u.next
+}
+
+func (t *token32) getToken32() token32 {
+	return token32{pegRule: t.pegRule, begin: uint32(t.begin), end: uint32(t.end), next: uint32(t.next)}
+}
+
+func (t *token32) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v %v", rul3s[t.pegRule], t.begin, t.end, t.next)
+}
+
+type tokens32 struct {
+	tree    []token32
+	ordered [][]token32
+}
+
+func (t *tokens32) trim(length int) {
+	t.tree = t.tree[0:length]
+}
+
+func (t *tokens32) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens32) Order() [][]token32 {
+	if t.ordered != nil {
+		return t.ordered
+	}
+
+	depths := make([]int32, 1, math.MaxInt16)
+	for i, token := range t.tree {
+		if token.pegRule == ruleUnknown {
+			t.tree = t.tree[:i]
+			break
+		}
+		depth := int(token.next)
+		if length := len(depths); depth >= length {
+			depths = depths[:depth+1]
+		}
+		depths[depth]++
+	}
+	depths = append(depths, 0)
+
+	ordered, pool := make([][]token32, len(depths)), make([]token32, len(t.tree)+len(depths))
+	for i, depth := range depths {
+		depth++
+		ordered[i], pool, depths[i] = pool[:depth], pool[depth:], 0
+	}
+
+	for i, token := range t.tree {
+		depth := token.next
+		token.next = uint32(i)
+		ordered[depth][depths[depth]] = token
+		depths[depth]++
+	}
+	t.ordered = ordered
+	return ordered
+}
+
+type state32 struct {
+	token32
+	depths []int32
+	leaf   bool
+}
+
+func (t *tokens32) AST() *node32 {
+	tokens := t.Tokens()
+	stack := &element{node: &node32{token32: <-tokens}}
+	for token := range tokens {
+		if token.begin == token.end {
+			continue
+		}
+		node := &node32{token32: token}
+		for stack != nil && stack.node.begin >= token.begin && stack.node.end <= token.end {
+			stack.node.next = node.up
+			node.up = stack.node
+			stack = stack.down
+		}
+		stack = &element{node: node, down: stack}
+	}
+	return stack.node
+}
+
+func (t *tokens32) PreOrder() (<-chan state32, [][]token32) {
+	s, ordered := make(chan state32, 6), t.Order()
+	go func() {
+		var states [8]state32
+		for i := range states {
+			states[i].depths = make([]int32, len(ordered))
+		}
+		depths, state, depth := make([]int32, len(ordered)), 0, 1
+		write := func(t token32, leaf bool) {
+			S := states[state]
+			state, S.pegRule, S.begin, S.end, S.next, S.leaf = (state+1)%8, t.pegRule, t.begin, t.end, uint32(depth), leaf
+			copy(S.depths, depths)
+			s <- S
+		}
+
+		states[state].token32 = ordered[0][0]
+		depths[0]++
+		state++
+		a, b := ordered[depth-1][depths[depth-1]-1], ordered[depth][depths[depth]]
+	depthFirstSearch:
+		for {
+			for {
+				if i := depths[depth]; i > 0 {
+					if c, j := ordered[depth][i-1], depths[depth-1]; a.isParentOf(c) &&
+						(j < 2 || !ordered[depth-1][j-2].isParentOf(c)) {
+						if c.end != b.begin {
+							write(token32{pegRule: ruleIn, begin: c.end, end: b.begin}, true)
+						}
+						break
+					}
+				}
+
+				if a.begin < b.begin {
+					write(token32{pegRule: rulePre, begin: a.begin, end: b.begin}, true)
+				}
+				break
+			}
+
+			next := depth + 1
+			if c := ordered[next][depths[next]]; c.pegRule != ruleUnknown && b.isParentOf(c) {
+				write(b, false)
+				depths[depth]++
+				depth, a, b = next, b, c
+				continue
+			}
+
+			write(b, true)
+			depths[depth]++
+			c, parent := ordered[depth][depths[depth]], true
+			for {
+				if c.pegRule != ruleUnknown && a.isParentOf(c) {
+					b = c
+					continue depthFirstSearch
+				} else if parent && b.end != a.end {
+					write(token32{pegRule: ruleSuf, begin: b.end, end: a.end}, true)
+				}
+
+				depth--
+				if depth > 0 {
+					a, b, c = ordered[depth-1][depths[depth-1]-1], a, ordered[depth][depths[depth]]
+					parent = a.isParentOf(b)
+					continue
+				}
+
+				break depthFirstSearch
+			}
+		}
+
+		close(s)
+	}()
+	return s, ordered
+}
+
+func (t *tokens32) PrintSyntax() {
+	tokens, ordered := t.PreOrder()
+	max := -1
+	for token := range tokens {
+		if !token.leaf {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[36m%v\x1B[m", rul3s[ordered[i][depths[i]-1].pegRule])
+			}
+			fmt.Printf(" \x1B[36m%v\x1B[m\n", rul3s[token.pegRule])
+		} else if token.begin == token.end {
+			fmt.Printf("%v", token.begin)
+			for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+				fmt.Printf(" \x1B[31m%v\x1B[m", rul3s[ordered[i][depths[i]-1].pegRule])
+			}
+			fmt.Printf(" \x1B[31m%v\x1B[m\n", rul3s[token.pegRule])
+		} else {
+			for c, end := token.begin, token.end; c < end; c++ {
+				if i := int(c); max+1 < i {
+					for j := max; j < i; j++ {
+						fmt.Printf("skip %v %v\n", j, token.String())
+					}
+					max = i
+				} else if i := int(c); i <= max {
+					for j := i; j <= max; j++ {
+						fmt.Printf("dupe %v %v\n", j, token.String())
+					}
+				} else {
+					max = int(c)
+				}
+				fmt.Printf("%v", c)
+				for i, leaf, depths := 0, int(token.next), token.depths; i < leaf; i++ {
+					fmt.Printf(" \x1B[34m%v\x1B[m", rul3s[ordered[i][depths[i]-1].pegRule])
+				}
+				fmt.Printf(" \x1B[34m%v\x1B[m\n", rul3s[token.pegRule])
+			}
+			fmt.Printf("\n")
+		}
+	}
+}
+
+func (t *tokens32) PrintSyntaxTree(buffer string) {
+	tokens, _ := t.PreOrder()
+	for token := range tokens {
+		for c := 0; c < int(token.next); c++ {
+			fmt.Printf(" ")
+		}
+		fmt.Printf("\x1B[34m%v\x1B[m %v\n", rul3s[token.pegRule], strconv.Quote(string(([]rune(buffer)[token.begin:token.end]))))
+	}
+}
+
+func (t *tokens32) Add(rule pegRule, begin, end, depth uint32, index int) {
+	t.tree[index] = token32{pegRule: rule, begin: uint32(begin), end: uint32(end), next: uint32(depth)}
+}
+
+func (t *tokens32) Tokens() <-chan token32 {
+	s := make(chan token32, 16)
+	go func() {
+		for _, v := range t.tree {
+			s <- v.getToken32()
+		}
+		close(s)
+	}()
+	return s
+}
+
+func (t *tokens32) Error() []token32 {
+	ordered := t.Order()
+	length := len(ordered)
+	tokens, length := make([]token32, length), length-1
+	for i := range tokens {
+		o := ordered[length-i]
+		if len(o) > 1 {
+			tokens[i] = o[len(o)-2].getToken32()
+		}
+	}
+	return tokens
+}
+
+func (t *tokens32) Expand(index int) {
+	tree := t.tree
+	if index >= len(tree) {
+		expanded := make([]token32, 2*len(tree))
+		copy(expanded, tree)
+		t.tree = expanded
+	}
+}
+
+type Scalar struct {
+	builder
+
+	Buffer string
+	buffer []rune
+	rules  [38]func() bool
+	Parse  func(rule ...int) error
+	Reset  func()
+	Pretty bool
+	tokens32
+}
+
+type textPosition struct {
+	line, symbol int
+}
+
+type textPositionMap map[int]textPosition
+
+func translatePositions(buffer []rune, positions []int) textPositionMap {
+	length, translations, j, line, symbol := len(positions), make(textPositionMap, len(positions)), 0, 1, 0
+	sort.Ints(positions)
+
+search:
+	for i, c := range buffer {
+		if c == '\n' {
+			line, symbol = line+1, 0
+		} else {
+			symbol++
+		}
+		if i == positions[j] {
+			translations[positions[j]] = textPosition{line, symbol}
+			for j++; j < length; j++ {
+				if i != positions[j] {
+					continue search
+				}
+			}
+			break search
+		}
+	}
+
+	return translations
+}
+
+type parseError struct {
+	p   *Scalar
+	max token32
+}
+
+func (e *parseError) Error() string {
+	tokens, error := []token32{e.max}, "\n"
+	positions, p := make([]int, 2*len(tokens)), 0
+	for _, token := range tokens {
+		positions[p], p = int(token.begin), p+1
+		positions[p], p = int(token.end), p+1
+	}
+	translations := translatePositions(e.p.buffer, positions)
+	format := "parse error near %v (line %v symbol %v - line %v symbol %v):\n%v\n"
+	if e.p.Pretty {
+		format = "parse error near \x1B[34m%v\x1B[m (line %v symbol %v - line %v symbol %v):\n%v\n"
+	}
+	for _, token := range tokens {
+		begin, end := int(token.begin), int(token.end)
+		error += fmt.Sprintf(format,
+			rul3s[token.pegRule],
+			translations[begin].line, translations[begin].symbol,
+			translations[end].line, translations[end].symbol,
+			strconv.Quote(string(e.p.buffer[begin:end])))
+	}
+
+	return error
+}
+
+func (p *Scalar) PrintSyntaxTree() {
+	p.tokens32.PrintSyntaxTree(p.Buffer)
+}
+
+func (p *Scalar) Highlighter() {
+	p.PrintSyntax()
+}
+
+func (p *Scalar) Execute() {
+	buffer, _buffer, text, begin, end := p.Buffer, p.buffer, "", 0, 0
+	for token := range p.Tokens() {
+		switch token.pegRule {
+
+		case rulePegText:
+			begin, end = int(token.begin), int(token.end)
+			text = string(_buffer[begin:end])
+
+		case ruleAction0:
+			p.finalize()
+		case ruleAction1:
+			p.setDescending()
+		case ruleAction2:
+			p.setDefault()
+		case ruleAction3:
+			p.pushBinary(ast.ArithAdd)
+		case ruleAction4:
+			p.pushBinary(ast.ArithSub)
+		case ruleAction5:
+			p.pushBinary(ast.ArithMul)
+		case ruleAction6:
+			p.pushBinary(ast.ArithDiv)
+		case ruleAction7:
+			p.pushNeg()
+		case ruleAction8:
+			p.pushCallStart(text)
+		case ruleAction9:
+			p.pushCall()
+		case ruleAction10:
+			p.pushFieldRef(text)
+		case ruleAction11:
+			p.pushTimeValue(time.RFC3339, text)
+		case ruleAction12:
+			p.pushTimeValue("2006-01-02", text)
+		case ruleAction13:
+			p.pushQuotedStringValue(text)
+		case ruleAction14:
+			p.pushIntegerValue(text)
+		case ruleAction15:
+			p.pushFloatValue(text)
+
+		}
+	}
+	_, _, _, _, _ = buffer, _buffer, text, begin, end
+}
+
+func (p *Scalar) Init() {
+	p.buffer = []rune(p.Buffer)
+	if len(p.buffer) == 0 || p.buffer[len(p.buffer)-1] != endSymbol {
+		p.buffer = append(p.buffer, endSymbol)
+	}
+
+	tree := tokens32{tree: make([]token32, math.MaxInt16)}
+	var max token32
+	position, depth, tokenIndex, buffer, _rules := uint32(0), uint32(0), 0, p.buffer, p.rules
+
+	p.Parse = func(rule ...int) error {
+		r := 1
+		if len(rule) > 0 {
+			r = rule[0]
+		}
+		matches := p.rules[r]()
+		p.tokens32 = tree
+		if matches {
+			p.trim(tokenIndex)
+			return nil
+		}
+		return &parseError{p, max}
+	}
+
+	p.Reset = func() {
+		position, tokenIndex, depth = 0, 0, 0
+	}
+
+	add := func(rule pegRule, begin uint32) {
+		tree.Expand(tokenIndex)
+		tree.Add(rule, begin, position, depth, tokenIndex)
+		tokenIndex++
+		if begin != position && position > max.end {
+			max = token32{rule, begin, position, depth}
+		}
+	}
+
+	matchDot := func() bool {
+		if buffer[position] != endSymbol {
+			position++
+			return true
+		}
+		return false
+	}
+
+	/*matchChar := func(c byte) bool {
+		if buffer[position] == c {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	/*matchRange := func(lower byte, upper byte) bool {
+		if c := buffer[position]; c >= lower && c <= upper {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	_rules = [...]func() bool{
+		nil,
+		/* 0 Start <- <(Spacing ((&{ p.field } Expr) / (&{ !p.field } Direction? Expr Spacing Default?)) Spacing !. Action0)> */
+		func() bool {
+			position0, tokenIndex0, depth0 := position, tokenIndex, depth
+			{
+				position1 := position
+				depth++
+				if !_rules[ruleSpacing]() {
+					goto l0
+				}
+				{
+					position2, tokenIndex2, depth2 := position, tokenIndex, depth
+					if !(p.field) {
+						goto l3
+					}
+					if !_rules[ruleExpr]() {
+						goto l3
+					}
+					goto l2
+				l3:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
+					if !(!p.field) {
+						goto l0
+					}
+					{
+						position4, tokenIndex4, depth4 := position, tokenIndex, depth
+						if !_rules[ruleDirection]() {
+							goto l4
+						}
+						goto l5
+					l4:
+						position, tokenIndex, depth = position4, tokenIndex4, depth4
+					}
+				l5:
+					if !_rules[ruleExpr]() {
+						goto l0
+					}
+					if !_rules[ruleSpacing]() {
+						goto l0
+					}
+					{
+						position6, tokenIndex6, depth6 := position, tokenIndex, depth
+						if !_rules[ruleDefault]() {
+							goto l6
+						}
+						goto l7
+					l6:
+						position, tokenIndex, depth = position6, tokenIndex6, depth6
+					}
+				l7:
+				}
+			l2:
+				if !_rules[ruleSpacing]() {
+					goto l0
+				}
+				{
+					position8, tokenIndex8, depth8 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l8
+					}
+					goto l0
+				l8:
+					position, tokenIndex, depth = position8, tokenIndex8, depth8
+				}
+				if !_rules[ruleAction0]() {
+					goto l0
+				}
+				depth--
+				add(ruleStart, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex, depth = position0, tokenIndex0, depth0
+			return false
+		},
+		/* 1 Direction <- <(('-' Spacing Action1) / ('+' Spacing))> */
+		func() bool {
+			position9, tokenIndex9, depth9 := position, tokenIndex, depth
+			{
+				position10 := position
+				depth++
+				{
+					position11, tokenIndex11, depth11 := position, tokenIndex, depth
+					if buffer[position] != rune('-') {
+						goto l12
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l12
+					}
+					if !_rules[ruleAction1]() {
+						goto l12
+					}
+					goto l11
+				l12:
+					position, tokenIndex, depth = position11, tokenIndex11, depth11
+					if buffer[position] != rune('+') {
+						goto l9
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l9
+					}
+				}
+			l11:
+				depth--
+				add(ruleDirection, position10)
+			}
+			return true
+		l9:
+			position, tokenIndex, depth = position9, tokenIndex9, depth9
+			return false
+		},
+		/* 2 Default <- <('D' 'E' 'F' 'A' 'U' 'L' 'T' !IdentChar Spacing Factor Action2)> */
+		func() bool {
+			position13, tokenIndex13, depth13 := position, tokenIndex, depth
+			{
+				position14 := position
+				depth++
+				if buffer[position] != rune('D') {
+					goto l13
+				}
+				position++
+				if buffer[position] != rune('E') {
+					goto l13
+				}
+				position++
+				if buffer[position] != rune('F') {
+					goto l13
+				}
+				position++
+				if buffer[position] != rune('A') {
+					goto l13
+				}
+				position++
+				if buffer[position] != rune('U') {
+					goto l13
+				}
+				position++
+				if buffer[position] != rune('L') {
+					goto l13
+				}
+				position++
+				if buffer[position] != rune('T') {
+					goto l13
+				}
+				position++
+				{
+					position15, tokenIndex15, depth15 := position, tokenIndex, depth
+					if !_rules[ruleIdentChar]() {
+						goto l15
+					}
+					goto l13
+				l15:
+					position, tokenIndex, depth = position15, tokenIndex15, depth15
+				}
+				if !_rules[ruleSpacing]() {
+					goto l13
+				}
+				if !_rules[ruleFactor]() {
+					goto l13
+				}
+				if !_rules[ruleAction2]() {
+					goto l13
+				}
+				depth--
+				add(ruleDefault, position14)
+			}
+			return true
+		l13:
+			position, tokenIndex, depth = position13, tokenIndex13, depth13
+			return false
+		},
+		/* 3 Expr <- <(Term ((Spacing '+' Spacing Term Action3) / (Spacing '-' Spacing Term Action4))*)> */
+		func() bool {
+			position16, tokenIndex16, depth16 := position, tokenIndex, depth
+			{
+				position17 := position
+				depth++
+				if !_rules[ruleTerm]() {
+					goto l16
+				}
+			l18:
+				{
+					position19, tokenIndex19, depth19 := position, tokenIndex, depth
+					{
+						position20, tokenIndex20, depth20 := position, tokenIndex, depth
+						if !_rules[ruleSpacing]() {
+							goto l21
+						}
+						if buffer[position] != rune('+') {
+							goto l21
+						}
+						position++
+						if !_rules[ruleSpacing]() {
+							goto l21
+						}
+						if !_rules[ruleTerm]() {
+							goto l21
+						}
+						if !_rules[ruleAction3]() {
+							goto l21
+						}
+						goto l20
+					l21:
+						position, tokenIndex, depth = position20, tokenIndex20, depth20
+						if !_rules[ruleSpacing]() {
+							goto l19
+						}
+						if buffer[position] != rune('-') {
+							goto l19
+						}
+						position++
+						if !_rules[ruleSpacing]() {
+							goto l19
+						}
+						if !_rules[ruleTerm]() {
+							goto l19
+						}
+						if !_rules[ruleAction4]() {
+							goto l19
+						}
+					}
+				l20:
+					goto l18
+				l19:
+					position, tokenIndex, depth = position19, tokenIndex19, depth19
+				}
+				depth--
+				add(ruleExpr, position17)
+			}
+			return true
+		l16:
+			position, tokenIndex, depth = position16, tokenIndex16, depth16
+			return false
+		},
+		/* 4 Term <- <(Factor ((Spacing '*' Spacing Factor Action5) / (Spacing '/' Spacing Factor Action6))*)> */
+		func() bool {
+			position22, tokenIndex22, depth22 := position, tokenIndex, depth
+			{
+				position23 := position
+				depth++
+				if !_rules[ruleFactor]() {
+					goto l22
+				}
+			l24:
+				{
+					position25, tokenIndex25, depth25 := position, tokenIndex, depth
+					{
+						position26, tokenIndex26, depth26 := position, tokenIndex, depth
+						if !_rules[ruleSpacing]() {
+							goto l27
+						}
+						if buffer[position] != rune('*') {
+							goto l27
+						}
+						position++
+						if !_rules[ruleSpacing]() {
+							goto l27
+						}
+						if !_rules[ruleFactor]() {
+							goto l27
+						}
+						if !_rules[ruleAction5]() {
+							goto l27
+						}
+						goto l26
+					l27:
+						position, tokenIndex, depth = position26, tokenIndex26, depth26
+						if !_rules[ruleSpacing]() {
+							goto l25
+						}
+						if buffer[position] != rune('/') {
+							goto l25
+						}
+						position++
+						if !_rules[ruleSpacing]() {
+							goto l25
+						}
+						if !_rules[ruleFactor]() {
+							goto l25
+						}
+						if !_rules[ruleAction6]() {
+							goto l25
+						}
+					}
+				l26:
+					goto l24
+				l25:
+					position, tokenIndex, depth = position25, tokenIndex25, depth25
+				}
+				depth--
+				add(ruleTerm, position23)
+			}
+			return true
+		l22:
+			position, tokenIndex, depth = position22, tokenIndex22, depth22
+			return false
+		},
+		/* 5 Factor <- <(('(' Spacing Expr Spacing ')') / ('-' Spacing Factor Action7) / Call / Literal / FieldRef)> */
+		func() bool {
+			position28, tokenIndex28, depth28 := position, tokenIndex, depth
+			{
+				position29 := position
+				depth++
+				{
+					position30, tokenIndex30, depth30 := position, tokenIndex, depth
+					if buffer[position] != rune('(') {
+						goto l31
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l31
+					}
+					if !_rules[ruleExpr]() {
+						goto l31
+					}
+					if !_rules[ruleSpacing]() {
+						goto l31
+					}
+					if buffer[position] != rune(')') {
+						goto l31
+					}
+					position++
+					goto l30
+				l31:
+					position, tokenIndex, depth = position30, tokenIndex30, depth30
+					if buffer[position] != rune('-') {
+						goto l32
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l32
+					}
+					if !_rules[ruleFactor]() {
+						goto l32
+					}
+					if !_rules[ruleAction7]() {
+						goto l32
+					}
+					goto l30
+				l32:
+					position, tokenIndex, depth = position30, tokenIndex30, depth30
+					if !_rules[ruleCall]() {
+						goto l33
+					}
+					goto l30
+				l33:
+					position, tokenIndex, depth = position30, tokenIndex30, depth30
+					if !_rules[ruleLiteral]() {
+						goto l34
+					}
+					goto l30
+				l34:
+					position, tokenIndex, depth = position30, tokenIndex30, depth30
+					if !_rules[ruleFieldRef]() {
+						goto l28
+					}
+				}
+			l30:
+				depth--
+				add(ruleFactor, position29)
+			}
+			return true
+		l28:
+			position, tokenIndex, depth = position28, tokenIndex28, depth28
+			return false
+		},
+		/* 6 Call <- <(<Ident> Action8 Spacing '(' Spacing Args? Spacing ')' Action9)> */
+		func() bool {
+			position35, tokenIndex35, depth35 := position, tokenIndex, depth
+			{
+				position36 := position
+				depth++
+				{
+					position37 := position
+					depth++
+					if !_rules[ruleIdent]() {
+						goto l35
+					}
+					depth--
+					add(rulePegText, position37)
+				}
+				if !_rules[ruleAction8]() {
+					goto l35
+				}
+				if !_rules[ruleSpacing]() {
+					goto l35
+				}
+				if buffer[position] != rune('(') {
+					goto l35
+				}
+				position++
+				if !_rules[ruleSpacing]() {
+					goto l35
+				}
+				{
+					position38, tokenIndex38, depth38 := position, tokenIndex, depth
+					if !_rules[ruleArgs]() {
+						goto l38
+					}
+					goto l39
+				l38:
+					position, tokenIndex, depth = position38, tokenIndex38, depth38
+				}
+			l39:
+				if !_rules[ruleSpacing]() {
+					goto l35
+				}
+				if buffer[position] != rune(')') {
+					goto l35
+				}
+				position++
+				if !_rules[ruleAction9]() {
+					goto l35
+				}
+				depth--
+				add(ruleCall, position36)
+			}
+			return true
+		l35:
+			position, tokenIndex, depth = position35, tokenIndex35, depth35
+			return false
+		},
+		/* 7 Args <- <(Expr (Spacing ',' Spacing Expr)*)> */
+		func() bool {
+			position40, tokenIndex40, depth40 := position, tokenIndex, depth
+			{
+				position41 := position
+				depth++
+				if !_rules[ruleExpr]() {
+					goto l40
+				}
+			l42:
+				{
+					position43, tokenIndex43, depth43 := position, tokenIndex, depth
+					if !_rules[ruleSpacing]() {
+						goto l43
+					}
+					if buffer[position] != rune(',') {
+						goto l43
+					}
+					position++
+					if !_rules[ruleSpacing]() {
+						goto l43
+					}
+					if !_rules[ruleExpr]() {
+						goto l43
+					}
+					goto l42
+				l43:
+					position, tokenIndex, depth = position43, tokenIndex43, depth43
+				}
+				depth--
+				add(ruleArgs, position41)
+			}
+			return true
+		l40:
+			position, tokenIndex, depth = position40, tokenIndex40, depth40
+			return false
+		},
+		/* 8 FieldRef <- <(<(Ident ('.' Ident)*)> Action10)> */
+		func() bool {
+			position44, tokenIndex44, depth44 := position, tokenIndex, depth
+			{
+				position45 := position
+				depth++
+				{
+					position46 := position
+					depth++
+					if !_rules[ruleIdent]() {
+						goto l44
+					}
+				l47:
+					{
+						position48, tokenIndex48, depth48 := position, tokenIndex, depth
+						if buffer[position] != rune('.') {
+							goto l48
+						}
+						position++
+						if !_rules[ruleIdent]() {
+							goto l48
+						}
+						goto l47
+					l48:
+						position, tokenIndex, depth = position48, tokenIndex48, depth48
+					}
+					depth--
+					add(rulePegText, position46)
+				}
+				if !_rules[ruleAction10]() {
+					goto l44
+				}
+				depth--
+				add(ruleFieldRef, position45)
+			}
+			return true
+		l44:
+			position, tokenIndex, depth = position44, tokenIndex44, depth44
+			return false
+		},
+		/* 9 Ident <- <(([a-z] / [A-Z] / '_') IdentChar*)> */
+		func() bool {
+			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			{
+				position50 := position
+				depth++
+				{
+					position51, tokenIndex51, depth51 := position, tokenIndex, depth
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l52
+					}
+					position++
+					goto l51
+				l52:
+					position, tokenIndex, depth = position51, tokenIndex51, depth51
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l53
+					}
+					position++
+					goto l51
+				l53:
+					position, tokenIndex, depth = position51, tokenIndex51, depth51
+					if buffer[position] != rune('_') {
+						goto l49
+					}
+					position++
+				}
+			l51:
+			l54:
+				{
+					position55, tokenIndex55, depth55 := position, tokenIndex, depth
+					if !_rules[ruleIdentChar]() {
+						goto l55
+					}
+					goto l54
+				l55:
+					position, tokenIndex, depth = position55, tokenIndex55, depth55
+				}
+				depth--
+				add(ruleIdent, position50)
+			}
+			return true
+		l49:
+			position, tokenIndex, depth = position49, tokenIndex49, depth49
+			return false
+		},
+		/* 10 IdentChar <- <('_' / [a-z] / [A-Z] / [0-9])> */
+		func() bool {
+			position56, tokenIndex56, depth56 := position, tokenIndex, depth
+			{
+				position57 := position
+				depth++
+				{
+					position58, tokenIndex58, depth58 := position, tokenIndex, depth
+					if buffer[position] != rune('_') {
+						goto l59
+					}
+					position++
+					goto l58
+				l59:
+					position, tokenIndex, depth = position58, tokenIndex58, depth58
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l60
+					}
+					position++
+					goto l58
+				l60:
+					position, tokenIndex, depth = position58, tokenIndex58, depth58
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l61
+					}
+					position++
+					goto l58
+				l61:
+					position, tokenIndex, depth = position58, tokenIndex58, depth58
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l56
+					}
+					position++
+				}
+			l58:
+				depth--
+				add(ruleIdentChar, position57)
+			}
+			return true
+		l56:
+			position, tokenIndex, depth = position56, tokenIndex56, depth56
+			return false
+		},
+		/* 11 Literal <- <(Time / Float / Integer / QuotedString)> */
+		func() bool {
+			position62, tokenIndex62, depth62 := position, tokenIndex, depth
+			{
+				position63 := position
+				depth++
+				{
+					position64, tokenIndex64, depth64 := position, tokenIndex, depth
+					if !_rules[ruleTime]() {
+						goto l65
+					}
+					goto l64
+				l65:
+					position, tokenIndex, depth = position64, tokenIndex64, depth64
+					if !_rules[ruleFloat]() {
+						goto l66
+					}
+					goto l64
+				l66:
+					position, tokenIndex, depth = position64, tokenIndex64, depth64
+					if !_rules[ruleInteger]() {
+						goto l67
+					}
+					goto l64
+				l67:
+					position, tokenIndex, depth = position64, tokenIndex64, depth64
+					if !_rules[ruleQuotedString]() {
+						goto l62
+					}
+				}
+			l64:
+				depth--
+				add(ruleLiteral, position63)
+			}
+			return true
+		l62:
+			position, tokenIndex, depth = position62, tokenIndex62, depth62
+			return false
+		},
+		/* 12 Time <- <((<([1-9] [0-9] [0-9] [0-9] '-' [0-9] [0-9] '-' [0-9] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] ':' [0-9] [0-9] 'Z')> Action11) / (<([1-9] [0-9] [0-9] [0-9] '-' [0-9] [0-9] '-' [0-9] [0-9])> Action12))> */
+		func() bool {
+			position68, tokenIndex68, depth68 := position, tokenIndex, depth
+			{
+				position69 := position
+				depth++
+				{
+					position70, tokenIndex70, depth70 := position, tokenIndex, depth
+					{
+						position72 := position
+						depth++
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('T') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune(':') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune(':') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l71
+						}
+						position++
+						if buffer[position] != rune('Z') {
+							goto l71
+						}
+						position++
+						depth--
+						add(rulePegText, position72)
+					}
+					if !_rules[ruleAction11]() {
+						goto l71
+					}
+					goto l70
+				l71:
+					position, tokenIndex, depth = position70, tokenIndex70, depth70
+					{
+						position73 := position
+						depth++
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l68
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l68
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
+						if buffer[position] != rune('-') {
+							goto l68
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l68
+						}
+						position++
+						depth--
+						add(rulePegText, position73)
+					}
+					if !_rules[ruleAction12]() {
+						goto l68
+					}
+				}
+			l70:
+				depth--
+				add(ruleTime, position69)
+			}
+			return true
+		l68:
+			position, tokenIndex, depth = position68, tokenIndex68, depth68
+			return false
+		},
+		/* 13 QuotedString <- <('"' <(('\\' .) / (!('"' / '\\') .))*> '"' Action13)> */
+		func() bool {
+			position74, tokenIndex74, depth74 := position, tokenIndex, depth
+			{
+				position75 := position
+				depth++
+				if buffer[position] != rune('"') {
+					goto l74
+				}
+				position++
+				{
+					position76 := position
+					depth++
+				l77:
+					{
+						position78, tokenIndex78, depth78 := position, tokenIndex, depth
+						{
+							position79, tokenIndex79, depth79 := position, tokenIndex, depth
+							if buffer[position] != rune('\\') {
+								goto l80
+							}
+							position++
+							if !matchDot() {
+								goto l80
+							}
+							goto l79
+						l80:
+							position, tokenIndex, depth = position79, tokenIndex79, depth79
+							{
+								position81, tokenIndex81, depth81 := position, tokenIndex, depth
+								{
+									position82, tokenIndex82, depth82 := position, tokenIndex, depth
+									if buffer[position] != rune('"') {
+										goto l83
+									}
+									position++
+									goto l82
+								l83:
+									position, tokenIndex, depth = position82, tokenIndex82, depth82
+									if buffer[position] != rune('\\') {
+										goto l81
+									}
+									position++
+								}
+							l82:
+								goto l78
+							l81:
+								position, tokenIndex, depth = position81, tokenIndex81, depth81
+							}
+							if !matchDot() {
+								goto l78
+							}
+						}
+					l79:
+						goto l77
+					l78:
+						position, tokenIndex, depth = position78, tokenIndex78, depth78
+					}
+					depth--
+					add(rulePegText, position76)
+				}
+				if buffer[position] != rune('"') {
+					goto l74
+				}
+				position++
+				if !_rules[ruleAction13]() {
+					goto l74
+				}
+				depth--
+				add(ruleQuotedString, position75)
+			}
+			return true
+		l74:
+			position, tokenIndex, depth = position74, tokenIndex74, depth74
+			return false
+		},
+		/* 14 Integer <- <(<('0' / ([1-9] [0-9]*))> !IdentChar Action14)> */
+		func() bool {
+			position84, tokenIndex84, depth84 := position, tokenIndex, depth
+			{
+				position85 := position
+				depth++
+				{
+					position86 := position
+					depth++
+					{
+						position87, tokenIndex87, depth87 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l88
+						}
+						position++
+						goto l87
+					l88:
+						position, tokenIndex, depth = position87, tokenIndex87, depth87
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l84
+						}
+						position++
+					l89:
+						{
+							position90, tokenIndex90, depth90 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l90
+							}
+							position++
+							goto l89
+						l90:
+							position, tokenIndex, depth = position90, tokenIndex90, depth90
+						}
+					}
+				l87:
+					depth--
+					add(rulePegText, position86)
+				}
+				{
+					position91, tokenIndex91, depth91 := position, tokenIndex, depth
+					if !_rules[ruleIdentChar]() {
+						goto l91
+					}
+					goto l84
+				l91:
+					position, tokenIndex, depth = position91, tokenIndex91, depth91
+				}
+				if !_rules[ruleAction14]() {
+					goto l84
+				}
+				depth--
+				add(ruleInteger, position85)
+			}
+			return true
+		l84:
+			position, tokenIndex, depth = position84, tokenIndex84, depth84
+			return false
+		},
+		/* 15 Float <- <(<(('0' / ([1-9] [0-9]*)) '.' [0-9]+)> !IdentChar Action15)> */
+		func() bool {
+			position92, tokenIndex92, depth92 := position, tokenIndex, depth
+			{
+				position93 := position
+				depth++
+				{
+					position94 := position
+					depth++
+					{
+						position95, tokenIndex95, depth95 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l96
+						}
+						position++
+						goto l95
+					l96:
+						position, tokenIndex, depth = position95, tokenIndex95, depth95
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l92
+						}
+						position++
+					l97:
+						{
+							position98, tokenIndex98, depth98 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l98
+							}
+							position++
+							goto l97
+						l98:
+							position, tokenIndex, depth = position98, tokenIndex98, depth98
+						}
+					}
+				l95:
+					if buffer[position] != rune('.') {
+						goto l92
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l92
+					}
+					position++
+				l99:
+					{
+						position100, tokenIndex100, depth100 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l100
+						}
+						position++
+						goto l99
+					l100:
+						position, tokenIndex, depth = position100, tokenIndex100, depth100
+					}
+					depth--
+					add(rulePegText, position94)
+				}
+				{
+					position101, tokenIndex101, depth101 := position, tokenIndex, depth
+					if !_rules[ruleIdentChar]() {
+						goto l101
+					}
+					goto l92
+				l101:
+					position, tokenIndex, depth = position101, tokenIndex101, depth101
+				}
+				if !_rules[ruleAction15]() {
+					goto l92
+				}
+				depth--
+				add(ruleFloat, position93)
+			}
+			return true
+		l92:
+			position, tokenIndex, depth = position92, tokenIndex92, depth92
+			return false
+		},
+		/* 16 Spacing <- <(Space / Comment)*> */
+		func() bool {
+			{
+				position103 := position
+				depth++
+			l104:
+				{
+					position105, tokenIndex105, depth105 := position, tokenIndex, depth
+					{
+						position106, tokenIndex106, depth106 := position, tokenIndex, depth
+						if !_rules[ruleSpace]() {
+							goto l107
+						}
+						goto l106
+					l107:
+						position, tokenIndex, depth = position106, tokenIndex106, depth106
+						if !_rules[ruleComment]() {
+							goto l105
+						}
+					}
+				l106:
+					goto l104
+				l105:
+					position, tokenIndex, depth = position105, tokenIndex105, depth105
+				}
+				depth--
+				add(ruleSpacing, position103)
+			}
+			return true
+		},
+		/* 17 Comment <- <('#' (!EndOfLine .)*)> */
+		func() bool {
+			position108, tokenIndex108, depth108 := position, tokenIndex, depth
+			{
+				position109 := position
+				depth++
+				if buffer[position] != rune('#') {
+					goto l108
+				}
+				position++
+			l110:
+				{
+					position111, tokenIndex111, depth111 := position, tokenIndex, depth
+					{
+						position112, tokenIndex112, depth112 := position, tokenIndex, depth
+						if !_rules[ruleEndOfLine]() {
+							goto l112
+						}
+						goto l111
+					l112:
+						position, tokenIndex, depth = position112, tokenIndex112, depth112
+					}
+					if !matchDot() {
+						goto l111
+					}
+					goto l110
+				l111:
+					position, tokenIndex, depth = position111, tokenIndex111, depth111
+				}
+				depth--
+				add(ruleComment, position109)
+			}
+			return true
+		l108:
+			position, tokenIndex, depth = position108, tokenIndex108, depth108
+			return false
+		},
+		/* 18 Space <- <(' ' / '\t' / EndOfLine)> */
+		func() bool {
+			position113, tokenIndex113, depth113 := position, tokenIndex, depth
+			{
+				position114 := position
+				depth++
+				{
+					position115, tokenIndex115, depth115 := position, tokenIndex, depth
+					if buffer[position] != rune(' ') {
+						goto l116
+					}
+					position++
+					goto l115
+				l116:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
+					if buffer[position] != rune('\t') {
+						goto l117
+					}
+					position++
+					goto l115
+				l117:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
+					if !_rules[ruleEndOfLine]() {
+						goto l113
+					}
+				}
+			l115:
+				depth--
+				add(ruleSpace, position114)
+			}
+			return true
+		l113:
+			position, tokenIndex, depth = position113, tokenIndex113, depth113
+			return false
+		},
+		/* 19 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		func() bool {
+			position118, tokenIndex118, depth118 := position, tokenIndex, depth
+			{
+				position119 := position
+				depth++
+				{
+					position120, tokenIndex120, depth120 := position, tokenIndex, depth
+					if buffer[position] != rune('\r') {
+						goto l121
+					}
+					position++
+					if buffer[position] != rune('\n') {
+						goto l121
+					}
+					position++
+					goto l120
+				l121:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
+					if buffer[position] != rune('\n') {
+						goto l122
+					}
+					position++
+					goto l120
+				l122:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
+					if buffer[position] != rune('\r') {
+						goto l118
+					}
+					position++
+				}
+			l120:
+				depth--
+				add(ruleEndOfLine, position119)
+			}
+			return true
+		l118:
+			position, tokenIndex, depth = position118, tokenIndex118, depth118
+			return false
+		},
+		/* 21 Action0 <- <{ p.finalize() }> */
+		func() bool {
+			{
+				add(ruleAction0, position)
+			}
+			return true
+		},
+		/* 22 Action1 <- <{ p.setDescending() }> */
+		func() bool {
+			{
+				add(ruleAction1, position)
+			}
+			return true
+		},
+		/* 23 Action2 <- <{ p.setDefault() }> */
+		func() bool {
+			{
+				add(ruleAction2, position)
+			}
+			return true
+		},
+		/* 24 Action3 <- <{ p.pushBinary(ast.ArithAdd) }> */
+		func() bool {
+			{
+				add(ruleAction3, position)
+			}
+			return true
+		},
+		/* 25 Action4 <- <{ p.pushBinary(ast.ArithSub) }> */
+		func() bool {
+			{
+				add(ruleAction4, position)
+			}
+			return true
+		},
+		/* 26 Action5 <- <{ p.pushBinary(ast.ArithMul) }> */
+		func() bool {
+			{
+				add(ruleAction5, position)
+			}
+			return true
+		},
+		/* 27 Action6 <- <{ p.pushBinary(ast.ArithDiv) }> */
+		func() bool {
+			{
+				add(ruleAction6, position)
+			}
+			return true
+		},
+		/* 28 Action7 <- <{ p.pushNeg() }> */
+		func() bool {
+			{
+				add(ruleAction7, position)
+			}
+			return true
+		},
+		nil,
+		/* 30 Action8 <- <{ p.pushCallStart(text) }> */
+		func() bool {
+			{
+				add(ruleAction8, position)
+			}
+			return true
+		},
+		/* 31 Action9 <- <{ p.pushCall() }> */
+		func() bool {
+			{
+				add(ruleAction9, position)
+			}
+			return true
+		},
+		/* 32 Action10 <- <{ p.pushFieldRef(text) }> */
+		func() bool {
+			{
+				add(ruleAction10, position)
+			}
+			return true
+		},
+		/* 33 Action11 <- <{ p.pushTimeValue(time.RFC3339, text) }> */
+		func() bool {
+			{
+				add(ruleAction11, position)
+			}
+			return true
+		},
+		/* 34 Action12 <- <{ p.pushTimeValue("2006-01-02", text) }> */
+		func() bool {
+			{
+				add(ruleAction12, position)
+			}
+			return true
+		},
+		/* 35 Action13 <- <{ p.pushQuotedStringValue(text) }> */
+		func() bool {
+			{
+				add(ruleAction13, position)
+			}
+			return true
+		},
+		/* 36 Action14 <- <{ p.pushIntegerValue(text) }> */
+		func() bool {
+			{
+				add(ruleAction14, position)
+			}
+			return true
+		},
+		/* 37 Action15 <- <{ p.pushFloatValue(text) }> */
+		func() bool {
+			{
+				add(ruleAction15, position)
+			}
+			return true
+		},
+	}
+	p.rules = _rules
+}
@@ -0,0 +1,153 @@
+// Package jsonlogicquery translates query expressions into JSON Logic rules, see https://jsonlogic.com.
+//
+// Comparisons become rules on variables, e.g. pages < 500 becomes
+//
+//	{"<": [{"var": "pages"}, 500]}
+//
+// and text searches become "in" rules, which test substrings of strings.
+package jsonlogicquery
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/jsonlogicquery"
+)
+
+type Translator struct {
+	// KeywordFields are the string variables searched by keywords outside of property:expr.
+	// A keyword is an error if KeywordFields is empty.
+	KeywordFields []string
+
+	// TimeFormat formats time values as strings. JSON Logic has no time values, so a time value is an error
+	// if TimeFormat is empty. Data must hold times in the same format, e.g. time.RFC3339 in UTC, for them to
+	// compare correctly.
+	TimeFormat string
+}
+
+// Translate returns the rule for expr, which can be marshaled by encoding/json.
+func (t *Translator) Translate(expr ast.Expr) (map[string]interface{}, error) {
+	return t.expr(expr, "")
+}
+
+// expr translates expr, field is non-empty inside of property:expr.
+func (t *Translator) expr(expr ast.Expr, field string) (map[string]interface{}, error) {
+	switch e := expr.(type) {
+	case ast.And:
+		return t.join("and", []ast.Expr(e), field)
+	case ast.Or:
+		return t.join("or", []ast.Expr(e), field)
+	case *ast.Not:
+		rule, err := t.expr(e.Expr, field)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"!": rule}, nil
+	case *ast.OperatorExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		op, ok := operators[e.Operator]
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid operator %v", pkgName, e.Operator)
+		}
+		v, err := t.value(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			op: []interface{}{variable(e.Property), v},
+		}, nil
+	case *ast.ColonExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		return t.expr(e.Expr, e.Property)
+	case *ast.KeywordExpr:
+		if e.Stem {
+			return nil, fmt.Errorf("%s: stemmed keyword ~%v has no JSON Logic equivalent", pkgName, e.Value)
+		}
+		if field != "" {
+			return t.keyword(field, e.Value)
+		}
+		if len(t.KeywordFields) == 0 {
+			return nil, fmt.Errorf("%s: keyword %v has no JSON Logic equivalent without keyword fields", pkgName, e.Value)
+		}
+		var rules []interface{}
+		for _, f := range t.KeywordFields {
+			rule, err := t.keyword(f, e.Value)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+		if len(rules) == 1 {
+			return rules[0].(map[string]interface{}), nil
+		}
+		return map[string]interface{}{"or": rules}, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+}
+
+func (t *Translator) join(op string, exprs []ast.Expr, field string) (map[string]interface{}, error) {
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("%s: empty %s expression", pkgName, op)
+	}
+	var rules []interface{}
+	for _, v := range exprs {
+		rule, err := t.expr(v, field)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return map[string]interface{}{op: rules}, nil
+}
+
+// keyword returns a search of v in field, strings are searched as substrings and others are compared.
+func (t *Translator) keyword(field string, v ast.Value) (map[string]interface{}, error) {
+	jv, err := t.value(v)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := v.(ast.StringValue); ok {
+		return map[string]interface{}{
+			"in": []interface{}{jv, variable(field)},
+		}, nil
+	}
+	return map[string]interface{}{
+		"==": []interface{}{variable(field), jv},
+	}, nil
+}
+
+var operators = map[ast.Op]string{
+	ast.OpEq:  "==",
+	ast.OpNeq: "!=",
+	ast.OpLt:  "<",
+	ast.OpLe:  "<=",
+	ast.OpGt:  ">",
+	ast.OpGe:  ">=",
+}
+
+func variable(name string) map[string]interface{} {
+	return map[string]interface{}{"var": name}
+}
+
+func (t *Translator) value(v ast.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case ast.TimeValue:
+		if t.TimeFormat == "" {
+			return nil, fmt.Errorf("%s: time value %v has no JSON Logic equivalent without time format", pkgName, v.Raw())
+		}
+		return time.Time(v).Format(t.TimeFormat), nil
+	case nil:
+		return nil, fmt.Errorf("%s: nil value", pkgName)
+	default:
+		return v.Raw(), nil
+	}
+}
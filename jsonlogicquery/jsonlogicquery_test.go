@@ -0,0 +1,67 @@
+package jsonlogicquery
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	tr := &Translator{KeywordFields: []string{"title", "body"}, TimeFormat: time.RFC3339}
+	cases := []struct {
+		Query    string
+		Expected string
+	}{
+		{
+			`pages < 500`,
+			`{"<":[{"var":"pages"},500]}`,
+		},
+		{
+			`price >= 9.5 available != false`,
+			`{"and":[{">=":[{"var":"price"},9.5]},{"!=":[{"var":"available"},false]}]}`,
+		},
+		{
+			`NOT title:Harry OR pages:5`,
+			`{"or":[{"!":{"in":["Harry",{"var":"title"}]}},{"==":[{"var":"pages"},5]}]}`,
+		},
+		{
+			`cat`,
+			`{"or":[{"in":["cat",{"var":"title"}]},{"in":["cat",{"var":"body"}]}]}`,
+		},
+		{
+			`create_time > 2020-01-02`,
+			`{">":[{"var":"create_time"},"2020-01-02T00:00:00Z"]}`,
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		rule, err := tr.Translate(expr)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		b, err := json.Marshal(rule)
+		if assert.NoError(t, err, c.Query) {
+			assert.JSONEq(t, c.Expected, string(b), c.Query)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			&ast.ColonExpr{Property: "title", Expr: &ast.KeywordExpr{Value: ast.StringValue("dog"), Stem: true}},
+			ast.Or{},
+			&ast.KeywordExpr{Value: ast.StringValue("x")},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+			&ast.OperatorExpr{Property: "a", Operator: ast.OpGt, Value: ast.TimeValue(time.Unix(0, 0))},
+		} {
+			_, err := (&Translator{}).Translate(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
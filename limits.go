@@ -0,0 +1,202 @@
+package searchquery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrQueryTooComplex is reported, wrapped in a *LimitError, when a query exceeds one of the limits given by
+// ParseOptions.
+var ErrQueryTooComplex = errors.New(pkgName + ": query too complex")
+
+type Limit string
+
+const (
+	LimitInputBytes Limit = "input bytes"
+	LimitDepth      Limit = "depth"
+	LimitNodes      Limit = "nodes"
+	LimitOrTerms    Limit = "or terms"
+)
+
+type LimitError struct {
+	// Limit is the exceeded limit.
+	Limit Limit
+
+	// Max is the configured maximum of Limit.
+	Max int
+
+	// Offset is the byte offset in the query where Limit was exceeded.
+	Offset int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s exceeds %d at offset %d", ErrQueryTooComplex, e.Limit, e.Max, e.Offset)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrQueryTooComplex
+}
+
+// limits holds maximums for a query, zero means unlimited.
+type limits struct {
+	MaxInputBytes int
+
+	MaxDepth int
+
+	MaxNodes int
+
+	MaxOrTerms int
+}
+
+// defaultMaxInputBytes bounds the input of Complete and Tokenize without WithMaxInputBytes, which run on
+// every keystroke. GAE limits queries to 2000 characters.
+const defaultMaxInputBytes = 2000
+
+// inputLimit returns the limit of WithMaxInputBytes in opts, or defaultMaxInputBytes.
+func inputLimit(opts []ParseOption) int {
+	var c parseConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.limits.MaxInputBytes > 0 {
+		return c.limits.MaxInputBytes
+	}
+	return defaultMaxInputBytes
+}
+
+// WithMaxInputBytes limits the length of a query in bytes.
+func WithMaxInputBytes(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.limits.MaxInputBytes = n
+	}
+}
+
+// WithMaxDepth limits how deep parentheses, NOT and property:expr can be nested.
+func WithMaxDepth(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.limits.MaxDepth = n
+	}
+}
+
+// WithMaxNodes limits the total number of expressions in a query.
+func WithMaxNodes(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.limits.MaxNodes = n
+	}
+}
+
+// WithMaxOrTerms limits the number of terms joined by a chain of OR.
+func WithMaxOrTerms(n int) ParseOption {
+	return func(c *parseConfig) {
+		c.limits.MaxOrTerms = n
+	}
+}
+
+// scanDepth returns a *LimitError if parentheses, NOT and property:expr in s are nested deeper than max.
+// It reads s before parsing, so that deeply nested queries are rejected without parsing them. The builder
+// checks the depth again, this only needs to never count more than the grammar.
+func scanDepth(s string, max int) error {
+	// unary counts NOT and property: waiting for their operand, per open parenthesis
+	unary := []int{0}
+	depth := 0
+	enter := func(offset int) error {
+		depth++
+		if depth > max {
+			return &LimitError{Limit: LimitDepth, Max: max, Offset: offset}
+		}
+		return nil
+	}
+	operand := func() {
+		depth -= unary[len(unary)-1]
+		unary[len(unary)-1] = 0
+	}
+	// next returns the offset of the next character but spaces from i
+	next := func(i int) int {
+		for i < len(s) && strings.IndexByte(" \t\r\n", s[i]) >= 0 {
+			i++
+		}
+		return i
+	}
+	// stem is set after "~", which takes a string, not an expression
+	stem := false
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case strings.IndexByte(" \t\r\n", c) >= 0:
+			i++
+			stem = false
+		case c == '~':
+			i++
+			stem = true
+		case c == '#':
+			for i < len(s) && s[i] != '\r' && s[i] != '\n' {
+				i++
+			}
+		case c == '(':
+			unary = append(unary, 0)
+			if err := enter(i); err != nil {
+				return err
+			}
+			i++
+		case c == ')':
+			if len(unary) > 1 {
+				operand()
+				unary = unary[:len(unary)-1]
+				depth--
+			}
+			operand()
+			i++
+		case c == ':':
+			unary[len(unary)-1]++
+			if err := enter(i); err != nil {
+				return err
+			}
+			i++
+		case strings.IndexByte("=!<>", c) >= 0:
+			i++
+		case c == '"':
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+			i++
+			operand()
+			stem = false
+		default:
+			// times have colons in them
+			digit := c >= '0' && c <= '9'
+			j := i
+			for j < len(s) && strings.IndexByte(" \t\r\n#()~\"=!<>", s[j]) < 0 && (s[j] != ':' || digit) {
+				j++
+			}
+			word := s[i:j]
+			switch k := next(j); {
+			case stem:
+				operand()
+			case k < len(s) && strings.IndexByte(":=!<>", s[k]) >= 0:
+				// a property
+			case word == "AND" || word == "OR":
+			default:
+				// NOT takes no space before its operand, so "NOTNOTx" is NOT NOT x
+				for ; strings.HasPrefix(word, "NOT") && (len(word) == 3 || isOperandStart(word[3])); word = word[3:] {
+					unary[len(unary)-1]++
+					if err := enter(j - len(word)); err != nil {
+						return err
+					}
+				}
+				if word != "" {
+					operand()
+				}
+			}
+			i = j
+			stem = false
+		}
+	}
+	return nil
+}
+
+// isOperandStart reports whether c begins an operand of NOT inside of a word.
+func isOperandStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-'
+}
@@ -0,0 +1,97 @@
+package searchquery
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithOptions_Limits(t *testing.T) {
+	cases := []struct {
+		Query  string
+		Option ParseOption
+		Limit  Limit
+		Offset int
+	}{
+		{`blue guitar`, WithMaxInputBytes(5), LimitInputBytes, 5},
+		{`a ((b)) c`, WithMaxDepth(1), LimitDepth, 3},
+		{`NOT NOT x`, WithMaxDepth(1), LimitDepth, 4},
+		{`a:b:c`, WithMaxDepth(1), LimitDepth, 3},
+		{`a b c`, WithMaxNodes(3), LimitNodes, 5},
+		{`a OR b OR c OR d`, WithMaxOrTerms(3), LimitOrTerms, 16},
+		{`(a OR b) OR c`, WithMaxOrTerms(2), LimitOrTerms, 13},
+		{`"日本" OR ((x))`, WithMaxDepth(1), LimitDepth, 13},
+	}
+	for _, c := range cases {
+		_, err := ParseWithOptions(c.Query, c.Option)
+		if !assert.True(t, errors.Is(err, ErrQueryTooComplex), "%s: %v", c.Query, err) {
+			continue
+		}
+		var lerr *LimitError
+		if assert.True(t, errors.As(err, &lerr), c.Query) {
+			assert.Equal(t, c.Limit, lerr.Limit, c.Query)
+			assert.Equal(t, c.Offset, lerr.Offset, c.Query)
+		}
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		for _, s := range []string{
+			`a ((b)) c`,
+			`a OR b OR c`,
+			`a:(b OR NOT c)`,
+		} {
+			_, err := ParseWithOptions(s, WithMaxDepth(3), WithMaxNodes(7), WithMaxOrTerms(3), WithMaxInputBytes(len(s)))
+			assert.NoError(t, err, s)
+		}
+	})
+
+	t.Run("depth before parsing", func(t *testing.T) {
+		// unbalanced, so the limit is reported before the parser could fail
+		s := strings.Repeat("(", 1000000)
+		_, err := ParseWithOptions(s, WithMaxDepth(100))
+		var lerr *LimitError
+		if assert.True(t, errors.As(err, &lerr), "%v", err) {
+			assert.Equal(t, LimitDepth, lerr.Limit)
+			assert.Equal(t, 100, lerr.Offset)
+		}
+
+		for s, depth := range map[string]int{
+			`NOT:x`:                                 1,
+			`NOT = 1 AND NOT (a) NOT b`:             2,
+			`a:(b OR NOT c) NOT d`:                  3,
+			`t >= 2020-01-01T10:00:00Z NOT "a(b" x`: 1,
+			"NOT a # (((\nNOT b":                    1,
+			`NOT ~"x" (NOT -3)`:                     2,
+			`NOTNOT x AND NOTE`:                     2,
+			`~NOTNOTx NOTNOTa:b`:                    1,
+		} {
+			_, err := ParseWithOptions(s, WithMaxDepth(depth))
+			assert.NoError(t, err, s)
+			if depth > 1 {
+				_, err = ParseWithOptions(s, WithMaxDepth(depth-1))
+				assert.True(t, errors.Is(err, ErrQueryTooComplex), "%s: %v", s, err)
+			}
+		}
+	})
+
+	t.Run("NOT without spaces", func(t *testing.T) {
+		s := strings.Repeat("NOT", 100000) + "x"
+		start := time.Now()
+		_, err := ParseWithOptions(s, WithMaxDepth(100))
+		assert.Less(t, int64(time.Since(start)), int64(time.Second))
+		var lerr *LimitError
+		if assert.True(t, errors.As(err, &lerr), "%v", err) {
+			assert.Equal(t, LimitDepth, lerr.Limit)
+			assert.Equal(t, 300, lerr.Offset)
+		}
+	})
+
+	t.Run("deeply nested", func(t *testing.T) {
+		s := strings.Repeat("(", 10000) + "a" + strings.Repeat(")", 10000)
+		_, err := ParseWithOptions(s, WithMaxDepth(100))
+		assert.True(t, errors.Is(err, ErrQueryTooComplex), "%v", err)
+	})
+}
@@ -0,0 +1,198 @@
+// Package lucenequery prints query expressions in the Lucene query syntax, as accepted by Solr's standard
+// query parser.
+package lucenequery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/lucenequery"
+)
+
+type Printer struct {
+	// DefaultField is the field of keywords outside of property:expr.
+	// Keywords are printed without a field if empty, so that the server's default field is searched.
+	DefaultField string
+
+	// TimeFormat formats time values in UTC, "2006-01-02T15:04:05Z" by default as Solr expects.
+	TimeFormat string
+}
+
+// Print returns the Lucene query for expr.
+func (p *Printer) Print(expr ast.Expr) (string, error) {
+	var b strings.Builder
+	if err := p.expr(&b, expr, ""); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// expr writes expr, field is non-empty inside of property:expr.
+func (p *Printer) expr(b *strings.Builder, expr ast.Expr, field string) error {
+	switch e := expr.(type) {
+	case ast.And:
+		if len(e) == 0 {
+			return fmt.Errorf("%s: empty and expression", pkgName)
+		}
+		b.WriteString("(")
+		positive := false
+		for _, v := range e {
+			if _, ok := v.(*ast.Not); !ok {
+				positive = true
+			}
+		}
+		// a query of negative clauses only matches nothing
+		if !positive {
+			b.WriteString("*:*")
+		}
+		for i, v := range e {
+			if i > 0 || !positive {
+				b.WriteString(" AND ")
+			}
+			if not, ok := v.(*ast.Not); ok {
+				b.WriteString("NOT ")
+				v = not.Expr
+			}
+			if err := p.expr(b, v, field); err != nil {
+				return err
+			}
+		}
+		b.WriteString(")")
+	case ast.Or:
+		if len(e) == 0 {
+			return fmt.Errorf("%s: empty or expression", pkgName)
+		}
+		b.WriteString("(")
+		for i, v := range e {
+			if i > 0 {
+				b.WriteString(" OR ")
+			}
+			if err := p.expr(b, v, field); err != nil {
+				return err
+			}
+		}
+		b.WriteString(")")
+	case *ast.Not:
+		b.WriteString("(*:* NOT ")
+		if err := p.expr(b, e.Expr, field); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case *ast.OperatorExpr:
+		if e.Property == "" {
+			return fmt.Errorf("%s: empty property", pkgName)
+		}
+		if e.Value == nil {
+			return fmt.Errorf("%s: property %q: nil value", pkgName, e.Property)
+		}
+		v := p.value(e.Value)
+		f := escape(e.Property)
+		switch e.Operator {
+		case ast.OpEq:
+			b.WriteString(f + ":" + v)
+		case ast.OpNeq:
+			b.WriteString("(*:* NOT " + f + ":" + v + ")")
+		case ast.OpLt:
+			b.WriteString(f + ":{* TO " + v + "}")
+		case ast.OpLe:
+			b.WriteString(f + ":[* TO " + v + "]")
+		case ast.OpGt:
+			b.WriteString(f + ":{" + v + " TO *}")
+		case ast.OpGe:
+			b.WriteString(f + ":[" + v + " TO *]")
+		default:
+			return fmt.Errorf("%s: invalid operator %v", pkgName, e.Operator)
+		}
+	case *ast.ColonExpr:
+		if e.Property == "" {
+			return fmt.Errorf("%s: empty property", pkgName)
+		}
+		// the field is applied to each term rather than grouped as field:(...), which can not hold NOT alone
+		return p.expr(b, e.Expr, e.Property)
+	case *ast.KeywordExpr:
+		if e.Value == nil {
+			return fmt.Errorf("%s: nil value", pkgName)
+		}
+		if e.Stem {
+			return fmt.Errorf("%s: stemmed keyword ~%v has no Lucene equivalent", pkgName, e.Value)
+		}
+		if field == "" {
+			field = p.DefaultField
+		}
+		if field != "" {
+			b.WriteString(escape(field) + ":")
+		}
+		b.WriteString(p.value(e.Value))
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func (p *Printer) value(v ast.Value) string {
+	switch v := v.(type) {
+	case ast.StringValue:
+		return term(string(v))
+	case ast.IntegerValue:
+		return escape(strconv.FormatInt(int64(v), 10))
+	case ast.FloatValue:
+		return escape(strconv.FormatFloat(float64(v), 'f', -1, 64))
+	case ast.BoolValue:
+		return strconv.FormatBool(bool(v))
+	case ast.TimeValue:
+		layout := p.TimeFormat
+		if layout == "" {
+			layout = "2006-01-02T15:04:05Z"
+		}
+		return escape(time.Time(v).UTC().Format(layout))
+	default:
+		s, _ := ast.AsString(v)
+		return term(s)
+	}
+}
+
+// term quotes s as a phrase if it has several words, otherwise escapes it.
+func term(s string) string {
+	if s != "" && strings.IndexFunc(s, unicode.IsSpace) < 0 && !isReserved(s) {
+		return escape(s)
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// isReserved reports whether s is an operator of the syntax.
+func isReserved(s string) bool {
+	switch s {
+	case "AND", "OR", "NOT", "TO":
+		return true
+	default:
+		return false
+	}
+}
+
+// escape escapes Lucene special characters in s.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`+-&|!(){}[]^"~*?:\/`, r) || unicode.IsSpace(r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
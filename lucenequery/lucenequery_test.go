@@ -0,0 +1,75 @@
+package lucenequery
+
+import (
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter_Print(t *testing.T) {
+	cases := []struct {
+		Printer  *Printer
+		Query    string
+		Expected string
+	}{
+		{
+			&Printer{},
+			`model:gibson date < 1965-01-01`,
+			`(model:gibson AND date:{* TO 1965\-01\-01T00\:00\:00Z})`,
+		},
+		{
+			&Printer{},
+			`title:"Harry Potter" AND pages >= 500 AND price <= 9.5 AND stock > -1`,
+			`(title:"Harry Potter" AND pages:[500 TO *] AND price:[* TO 9.5] AND stock:{\-1 TO *})`,
+		},
+		{
+			&Printer{},
+			`beverage:wine color:(red OR white) NOT country:france`,
+			`(beverage:wine AND (color:red OR color:white) AND NOT country:france)`,
+		},
+		{
+			&Printer{},
+			`NOT cat OR users.type != dogs`,
+			`((*:* NOT cat) OR (*:* NOT users.type:dogs))`,
+		},
+		{
+			&Printer{},
+			`NOT cat NOT dog`,
+			`(*:* AND NOT cat AND NOT dog)`,
+		},
+		{
+			&Printer{DefaultField: "text"},
+			`"C++" AND "a \"quoted\" word" AND "OR"`,
+			`(text:C\+\+ AND text:"a \"quoted\" word" AND text:"OR")`,
+		},
+		{
+			&Printer{TimeFormat: "2006-01-02"},
+			`date = 2020-02-03T04:05:06Z`,
+			`date:2020\-02\-03`,
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		s, err := c.Printer.Print(expr)
+		if assert.NoError(t, err, c.Query) {
+			assert.Equal(t, c.Expected, s, c.Query)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			&ast.ColonExpr{Property: "title", Expr: &ast.KeywordExpr{Value: ast.StringValue("dog"), Stem: true}},
+			ast.And{},
+			ast.Or{},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+		} {
+			_, err := (&Printer{}).Print(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
@@ -0,0 +1,471 @@
+package lucenequery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// Parser reads Lucene queries, as accepted by the classic query parser, into query expressions.
+//
+// Clauses are combined as the classic query parser does, so that a AND b OR c requires a and b while c is
+// optional. Wildcards, fuzzy and proximity searches, and boosts have no equivalent and are errors.
+type Parser struct {
+	// DefaultField is the field of terms without a field.
+	// Terms of DefaultField are parsed as keywords outside of property:expr, as Printer prints them.
+	DefaultField string
+
+	// DefaultOperator is the operator between clauses without AND or OR, "OR" by default as in Lucene.
+	DefaultOperator string
+}
+
+// Parse returns the expression of the Lucene query s.
+func (p *Parser) Parse(s string) (ast.Expr, error) {
+	defaultAnd := false
+	switch p.DefaultOperator {
+	case "", "OR":
+	case "AND":
+		defaultAnd = true
+	default:
+		return nil, fmt.Errorf("%s: invalid default operator %q", pkgName, p.DefaultOperator)
+	}
+	l := &luceneParser{
+		s:            s,
+		defaultField: p.DefaultField,
+		defaultAnd:   defaultAnd,
+	}
+	expr, err := l.clauses("")
+	if err != nil {
+		return nil, err
+	}
+	if l.pos < len(l.s) {
+		return nil, l.errorf("unexpected %q", l.s[l.pos])
+	}
+	if expr == nil {
+		return nil, fmt.Errorf("%s: query matching all documents has no equivalent", pkgName)
+	}
+	return expr, nil
+}
+
+type occur int
+
+const (
+	occurShould occur = iota
+	occurMust
+	occurMustNot
+)
+
+type clause struct {
+	occur occur
+
+	// expr is nil for *:*.
+	expr ast.Expr
+}
+
+type luceneParser struct {
+	s string
+
+	pos int
+
+	defaultField string
+
+	defaultAnd bool
+}
+
+func (l *luceneParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s: offset %d: %s", pkgName, l.pos, fmt.Sprintf(format, args...))
+}
+
+// clauses parses clauses up to the end of s or ")", field is the field of the enclosing field:(...).
+// It returns nil if the clauses match all documents.
+func (l *luceneParser) clauses(field string) (ast.Expr, error) {
+	var cs []clause
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.s) || l.s[l.pos] == ')' {
+			break
+		}
+		conj := ""
+		switch {
+		case l.consumeWord("AND"), l.consume("&&"):
+			conj = "AND"
+		case l.consumeWord("OR"), l.consume("||"):
+			conj = "OR"
+		}
+		if conj != "" {
+			l.skipSpace()
+			if len(cs) == 0 {
+				return nil, l.errorf("%s without left operand", conj)
+			}
+		}
+		mod := occurShould
+		switch {
+		case l.consume("+"):
+			mod = occurMust
+		case l.consume("-"), l.consume("!"), l.consumeWord("NOT"):
+			mod = occurMustNot
+			l.skipSpace()
+		}
+		expr, err := l.clause(field)
+		if err != nil {
+			return nil, err
+		}
+		// same as QueryParserBase.addClause
+		if len(cs) > 0 {
+			prev := &cs[len(cs)-1]
+			if conj == "AND" && prev.occur != occurMustNot {
+				prev.occur = occurMust
+			} else if conj == "OR" && l.defaultAnd && prev.occur != occurMustNot {
+				prev.occur = occurShould
+			}
+		}
+		c := clause{expr: expr}
+		switch {
+		case mod != occurShould:
+			c.occur = mod
+		case conj == "AND", conj == "" && l.defaultAnd:
+			c.occur = occurMust
+		default:
+			c.occur = occurShould
+		}
+		cs = append(cs, c)
+	}
+	if len(cs) == 0 {
+		return nil, l.errorf("empty query")
+	}
+	return l.combine(cs)
+}
+
+// combine builds the expression of cs, which requires all must clauses and at least one should clause if
+// there is no must clause.
+func (l *luceneParser) combine(cs []clause) (ast.Expr, error) {
+	var (
+		must      []ast.Expr
+		should    []ast.Expr
+		mustNot   []ast.Expr
+		hasMust   bool
+		shouldAll bool
+	)
+	for _, c := range cs {
+		switch c.occur {
+		case occurMust:
+			hasMust = true
+			if and, ok := c.expr.(ast.And); ok {
+				must = append(must, and...)
+			} else if c.expr != nil {
+				must = append(must, c.expr)
+			}
+		case occurShould:
+			if c.expr == nil {
+				shouldAll = true
+			} else {
+				should = append(should, c.expr)
+			}
+		case occurMustNot:
+			if c.expr == nil {
+				return nil, l.errorf("query matching no documents has no equivalent")
+			}
+			mustNot = append(mustNot, &ast.Not{Expr: c.expr})
+		}
+	}
+	if !hasMust && !shouldAll && len(should) > 0 {
+		if len(should) == 1 {
+			must = append(must, should[0])
+		} else {
+			must = append(must, ast.Or(should))
+		}
+	}
+	exprs := append(must, mustNot...)
+	switch len(exprs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return exprs[0], nil
+	default:
+		return ast.And(exprs), nil
+	}
+}
+
+// clause parses a term, phrase, range or group with an optional field.
+func (l *luceneParser) clause(field string) (ast.Expr, error) {
+	if l.pos >= len(l.s) {
+		return nil, l.errorf("unexpected end of query")
+	}
+	start := l.pos
+	explicit := false
+	if c := l.s[l.pos]; c != '(' && c != '"' && c != '[' && c != '{' {
+		name, wildcard, err := l.term()
+		if err != nil {
+			return nil, err
+		}
+		if l.consume(":") {
+			if name == "*" && wildcard && l.consume("*") {
+				return nil, nil
+			}
+			if wildcard {
+				l.pos = start
+				return nil, l.errorf("wildcard fields are not supported")
+			}
+			field = name
+			explicit = true
+		} else {
+			l.pos = start
+		}
+	}
+	if explicit && field == l.defaultField {
+		field = ""
+	}
+	var (
+		expr ast.Expr
+		err  error
+	)
+	switch {
+	case l.consume("("):
+		expr, err = l.clauses(field)
+		if err != nil {
+			return nil, err
+		}
+		if !l.consume(")") {
+			return nil, l.errorf("missing )")
+		}
+		if expr != nil && explicit && field != "" {
+			expr = &ast.ColonExpr{Property: field, Expr: expr}
+		}
+	case l.pos < len(l.s) && (l.s[l.pos] == '[' || l.s[l.pos] == '{'):
+		if field == "" {
+			field = l.defaultField
+		}
+		if field == "" {
+			return nil, l.errorf("range without field")
+		}
+		expr, err = l.rangeExpr(field)
+		if err != nil {
+			return nil, err
+		}
+	case l.consume(`"`):
+		s, err := l.phrase()
+		if err != nil {
+			return nil, err
+		}
+		expr = keyword(ast.StringValue(s), field, explicit)
+	default:
+		termPos := l.pos
+		s, wildcard, err := l.term()
+		if err != nil {
+			return nil, err
+		}
+		if wildcard {
+			l.pos = termPos
+			return nil, l.errorf("wildcard terms are not supported")
+		}
+		if s == "" {
+			return nil, l.errorf("unexpected %q", l.s[l.pos])
+		}
+		expr = keyword(inferValue(s), field, explicit)
+	}
+	if l.pos < len(l.s) {
+		switch l.s[l.pos] {
+		case '^':
+			return nil, l.errorf("boosts are not supported")
+		case '~':
+			return nil, l.errorf("fuzzy and proximity searches are not supported")
+		}
+	}
+	return expr, nil
+}
+
+// keyword returns a keyword of v, searched in field if given explicitly.
+func keyword(v ast.Value, field string, explicit bool) ast.Expr {
+	kw := &ast.KeywordExpr{Value: v}
+	if !explicit || field == "" {
+		return kw
+	}
+	return &ast.ColonExpr{Property: field, Expr: kw}
+}
+
+// rangeExpr parses a range such as [a TO b} into comparisons of field.
+func (l *luceneParser) rangeExpr(field string) (ast.Expr, error) {
+	lowerInclusive := l.s[l.pos] == '['
+	l.pos++
+	l.skipSpace()
+	lower, lowerAll, err := l.bound()
+	if err != nil {
+		return nil, err
+	}
+	l.skipSpace()
+	if !l.consumeWord("TO") {
+		return nil, l.errorf("missing TO in range")
+	}
+	l.skipSpace()
+	upper, upperAll, err := l.bound()
+	if err != nil {
+		return nil, err
+	}
+	l.skipSpace()
+	var upperInclusive bool
+	switch {
+	case l.consume("]"):
+		upperInclusive = true
+	case l.consume("}"):
+	default:
+		return nil, l.errorf("missing end of range")
+	}
+	var exprs ast.And
+	if !lowerAll {
+		op := ast.OpGt
+		if lowerInclusive {
+			op = ast.OpGe
+		}
+		exprs = append(exprs, &ast.OperatorExpr{Property: field, Operator: op, Value: lower})
+	}
+	if !upperAll {
+		op := ast.OpLt
+		if upperInclusive {
+			op = ast.OpLe
+		}
+		exprs = append(exprs, &ast.OperatorExpr{Property: field, Operator: op, Value: upper})
+	}
+	switch len(exprs) {
+	case 0:
+		return nil, l.errorf("unbounded ranges are not supported")
+	case 1:
+		return exprs[0], nil
+	default:
+		return exprs, nil
+	}
+}
+
+// bound parses an end of a range, all is true for *.
+func (l *luceneParser) bound() (v ast.Value, all bool, err error) {
+	if l.consume(`"`) {
+		s, err := l.phrase()
+		if err != nil {
+			return nil, false, err
+		}
+		return ast.StringValue(s), false, nil
+	}
+	start := l.pos
+	s, wildcard, err := l.term()
+	if err != nil {
+		return nil, false, err
+	}
+	if wildcard {
+		if l.s[start:l.pos] == "*" {
+			return nil, true, nil
+		}
+		l.pos = start
+		return nil, false, l.errorf("wildcard terms are not supported")
+	}
+	if s == "" {
+		return nil, false, l.errorf("missing range bound")
+	}
+	return inferValue(s), false, nil
+}
+
+// term reads an unescaped term up to a space or a special character.
+func (l *luceneParser) term() (s string, wildcard bool, err error) {
+	var b strings.Builder
+	for l.pos < len(l.s) {
+		r, n := utf8.DecodeRuneInString(l.s[l.pos:])
+		if unicode.IsSpace(r) || strings.ContainsRune(`()[]{}":^~`, r) {
+			break
+		}
+		if r == '\\' {
+			if l.pos+n >= len(l.s) {
+				return "", false, l.errorf("trailing backslash")
+			}
+			l.pos += n
+			r, n = utf8.DecodeRuneInString(l.s[l.pos:])
+		} else if r == '*' || r == '?' {
+			wildcard = true
+		}
+		b.WriteRune(r)
+		l.pos += n
+	}
+	return b.String(), wildcard, nil
+}
+
+// phrase reads the rest of a quoted phrase.
+func (l *luceneParser) phrase() (string, error) {
+	var b strings.Builder
+	for l.pos < len(l.s) {
+		c := l.s[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return b.String(), nil
+		case '\\':
+			if l.pos+1 >= len(l.s) {
+				return "", l.errorf("trailing backslash")
+			}
+			l.pos++
+			c = l.s[l.pos]
+		}
+		b.WriteByte(c)
+		l.pos++
+	}
+	return "", l.errorf("unterminated phrase")
+}
+
+func (l *luceneParser) skipSpace() {
+	for l.pos < len(l.s) {
+		r, n := utf8.DecodeRuneInString(l.s[l.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		l.pos += n
+	}
+}
+
+func (l *luceneParser) consume(s string) bool {
+	if !strings.HasPrefix(l.s[l.pos:], s) {
+		return false
+	}
+	l.pos += len(s)
+	return true
+}
+
+// consumeWord consumes the operator word, which must not continue as a term.
+func (l *luceneParser) consumeWord(word string) bool {
+	if !strings.HasPrefix(l.s[l.pos:], word) {
+		return false
+	}
+	end := l.pos + len(word)
+	if end < len(l.s) {
+		r, _ := utf8.DecodeRuneInString(l.s[end:])
+		if !unicode.IsSpace(r) && r != '(' && r != '"' {
+			return false
+		}
+	}
+	l.pos = end
+	return true
+}
+
+// inferValue returns the value of an unquoted term, numbers, booleans and times are typed as in queries.
+func inferValue(s string) ast.Value {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ast.IntegerValue(i)
+	}
+	if strings.Contains(s, ".") {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return ast.FloatValue(f)
+		}
+	}
+	switch s {
+	case "true":
+		return ast.BoolValue(true)
+	case "false":
+		return ast.BoolValue(false)
+	}
+	for _, layout := range ast.TimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return ast.TimeValue(t)
+		}
+	}
+	return ast.StringValue(s)
+}
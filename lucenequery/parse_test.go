@@ -0,0 +1,99 @@
+package lucenequery
+
+import (
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_Parse(t *testing.T) {
+	p := &Parser{DefaultField: "text"}
+	cases := []struct {
+		Query    string
+		Expected string
+	}{
+		{`harry`, `harry`},
+		{`title:potter AND pages:{* TO 500}`, `title:potter AND pages < 500`},
+		{`a AND b OR c`, `a AND b`},
+		{`a OR b`, `a OR b`},
+		{`+a -b c`, `a AND NOT b`},
+		{`a b !c`, `a OR b AND NOT c`},
+		{`(*:* NOT x)`, `NOT x`},
+		{`*:* AND text:x`, `x`},
+		{`color:(red OR white) && price:[1.5 TO 10]`, `color:(red OR white) AND price >= 1.5 AND price <= 10`},
+		{`title:"harry \"the\" potter" published:2020\-01\-02`, `title:"harry \"the\" potter" OR published:2020-01-02`},
+		{`pages:\-5 available:true`, `pages:-5 OR available:true`},
+		{`[a TO b]`, `text >= a AND text <= b`},
+	}
+	for _, c := range cases {
+		expr, err := p.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		expected, err := searchquery.Parse(c.Expected)
+		if assert.NoError(t, err, c.Expected) {
+			assert.Equal(t, expected, expr, c.Query)
+		}
+	}
+
+	t.Run("default operator", func(t *testing.T) {
+		expr, err := (&Parser{DefaultOperator: "AND"}).Parse(`a b`)
+		if assert.NoError(t, err) {
+			expected, _ := searchquery.Parse(`a AND b`)
+			assert.Equal(t, expected, expr)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, q := range []string{
+			`NOT cat OR dogs AND horses`,
+			`title:potter AND pages < 500 AND NOT color:red`,
+			`create_time > 2020-01-02T03:04:05Z price <= 9.5 "harry potter"`,
+		} {
+			expr, err := searchquery.Parse(q)
+			if !assert.NoError(t, err, q) {
+				continue
+			}
+			s, err := (&Printer{}).Print(expr)
+			if !assert.NoError(t, err, q) {
+				continue
+			}
+			actual, err := (&Parser{}).Parse(s)
+			if !assert.NoError(t, err, s) {
+				continue
+			}
+			expected, err := searchquery.Format(expr)
+			assert.NoError(t, err)
+			formatted, err := searchquery.Format(actual)
+			if assert.NoError(t, err, s) {
+				assert.Equal(t, expected, formatted, s)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, q := range []string{
+			``,
+			`*:*`,
+			`a AND`,
+			`OR a`,
+			`(a`,
+			`a)`,
+			`harr*`,
+			`title:pot?er`,
+			`a^2`,
+			`"a b"~3`,
+			`[1 TO 2]`,
+			`pages:[* TO *]`,
+			`pages:[1 2]`,
+			`"a`,
+			`-*:*`,
+		} {
+			_, err := (&Parser{}).Parse(q)
+			assert.Error(t, err, q)
+		}
+		_, err := (&Parser{DefaultOperator: "XOR"}).Parse(`a`)
+		assert.Error(t, err)
+	})
+}
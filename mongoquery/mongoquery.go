@@ -0,0 +1,232 @@
+// Package mongoquery translates query expressions into MongoDB filter documents.
+//
+// Filters are built as D, which has the same shape as bson.D of the MongoDB driver without depending on it:
+//
+//	filter := make(bson.D, 0, len(d))
+//	for _, e := range d {
+//		filter = append(filter, bson.E{Key: e.Key, Value: e.Value})
+//	}
+//
+// or as map[string]interface{} by D.Map, which converts to bson.M directly.
+package mongoquery
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/mongoquery"
+)
+
+// D is an ordered document.
+type D []E
+
+type E struct {
+	Key string
+
+	Value interface{}
+}
+
+// Map converts d and documents nested in d into maps.
+func (d D) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(d))
+	for _, e := range d {
+		m[e.Key] = toMap(e.Value)
+	}
+	return m
+}
+
+func toMap(v interface{}) interface{} {
+	switch v := v.(type) {
+	case D:
+		return v.Map()
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i := range v {
+			a[i] = toMap(v[i])
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+type Translator struct {
+	// KeywordFields are the fields searched by keywords outside of property:expr.
+	// If empty, keywords are searched by a $text, which requires a text index. A filter can have only one
+	// $text, so keywords are joined into it as phrases which all must match, and keywords under OR or NOT
+	// are errors.
+	KeywordFields []string
+}
+
+// Translate returns the filter document for expr.
+// Properties are used as field paths as is, so users.name refers to the embedded document field.
+func (t *Translator) Translate(expr ast.Expr) (D, error) {
+	if len(t.KeywordFields) > 0 {
+		return t.expr(expr, "")
+	}
+
+	// keywords ANDed at the top level are searched by a $text
+	var (
+		phrases []string
+		and     []interface{}
+	)
+	var walk func(expr ast.Expr) error
+	walk = func(expr ast.Expr) error {
+		switch e := expr.(type) {
+		case ast.And:
+			if len(e) == 0 {
+				return fmt.Errorf("%s: empty $and expression", pkgName)
+			}
+			for _, v := range e {
+				if err := walk(v); err != nil {
+					return err
+				}
+			}
+		case *ast.KeywordExpr:
+			if e.Stem {
+				return fmt.Errorf("%s: stemmed keyword ~%v has no MongoDB equivalent", pkgName, e.Value)
+			}
+			s, err := ast.AsString(e.Value)
+			if err != nil {
+				return err
+			}
+			phrases = append(phrases, textSearch(s))
+		default:
+			d, err := t.expr(expr, "")
+			if err != nil {
+				return err
+			}
+			and = append(and, d)
+		}
+		return nil
+	}
+	if err := walk(expr); err != nil {
+		return nil, err
+	}
+	if len(phrases) > 0 {
+		text := D{{Key: "$text", Value: D{{Key: "$search", Value: strings.Join(phrases, " ")}}}}
+		and = append([]interface{}{text}, and...)
+	}
+	if len(and) == 1 {
+		return and[0].(D), nil
+	}
+	return D{{Key: "$and", Value: and}}, nil
+}
+
+// expr translates expr, field is non-empty inside of property:expr.
+func (t *Translator) expr(expr ast.Expr, field string) (D, error) {
+	switch e := expr.(type) {
+	case ast.And:
+		return t.join("$and", []ast.Expr(e), field)
+	case ast.Or:
+		return t.join("$or", []ast.Expr(e), field)
+	case *ast.Not:
+		d, err := t.expr(e.Expr, field)
+		if err != nil {
+			return nil, err
+		}
+		return D{{Key: "$nor", Value: []interface{}{d}}}, nil
+	case *ast.OperatorExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		op, ok := operators[e.Operator]
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid operator %v", pkgName, e.Operator)
+		}
+		if e.Value == nil {
+			return nil, fmt.Errorf("%s: property %q: nil value", pkgName, e.Property)
+		}
+		return D{{Key: e.Property, Value: D{{Key: op, Value: e.Value.Raw()}}}}, nil
+	case *ast.ColonExpr:
+		if e.Property == "" {
+			return nil, fmt.Errorf("%s: empty property", pkgName)
+		}
+		return t.expr(e.Expr, e.Property)
+	case *ast.KeywordExpr:
+		if e.Stem {
+			return nil, fmt.Errorf("%s: stemmed keyword ~%v has no MongoDB equivalent", pkgName, e.Value)
+		}
+		s, err := ast.AsString(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		if field != "" {
+			return wordMatch(field, s), nil
+		}
+		if len(t.KeywordFields) == 0 {
+			return nil, fmt.Errorf("%s: keyword %q under OR or NOT needs KeywordFields", pkgName, s)
+		}
+		var or []interface{}
+		for _, f := range t.KeywordFields {
+			or = append(or, wordMatch(f, s))
+		}
+		if len(or) == 1 {
+			return or[0].(D), nil
+		}
+		return D{{Key: "$or", Value: or}}, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+}
+
+func (t *Translator) join(op string, exprs []ast.Expr, field string) (D, error) {
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("%s: empty %s expression", pkgName, op)
+	}
+	var a []interface{}
+	for _, v := range exprs {
+		d, err := t.expr(v, field)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, d)
+	}
+	return D{{Key: op, Value: a}}, nil
+}
+
+var operators = map[ast.Op]string{
+	ast.OpEq:  "$eq",
+	ast.OpNeq: "$ne",
+	ast.OpLt:  "$lt",
+	ast.OpLe:  "$lte",
+	ast.OpGt:  "$gt",
+	ast.OpGe:  "$gte",
+}
+
+// wordMatch matches s as whole words in field, case insensitively.
+func wordMatch(field, s string) D {
+	return D{{Key: field, Value: D{
+		{Key: "$regex", Value: wordPattern(s)},
+		{Key: "$options", Value: "i"},
+	}}}
+}
+
+// wordPattern returns the regular expression of s as whole words. \b of PCRE is between [A-Za-z0-9_] and
+// others, so it only bounds such edges of s.
+func wordPattern(s string) string {
+	pattern := regexp.QuoteMeta(s)
+	if r, _ := utf8.DecodeRuneInString(s); isWordRune(r) {
+		pattern = `\b` + pattern
+	}
+	if r, _ := utf8.DecodeLastRuneInString(s); isWordRune(r) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// textSearch quotes s as a phrase, so that $text does not treat "-" in s as negation.
+// Double quotes can not be escaped in $text, so they are removed.
+func textSearch(s string) string {
+	return `"` + strings.Replace(s, `"`, " ", -1) + `"`
+}
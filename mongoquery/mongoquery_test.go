@@ -0,0 +1,108 @@
+package mongoquery
+
+import (
+	"testing"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	cases := []struct {
+		Query    string
+		Expected D
+	}{
+		{
+			`users.name = kamichidu pages < 500`,
+			D{{"$and", []interface{}{
+				D{{"users.name", D{{"$eq", "kamichidu"}}}},
+				D{{"pages", D{{"$lt", int64(500)}}}},
+			}}},
+		},
+		{
+			`NOT published >= 2000-01-01 OR users.type != dogs`,
+			D{{"$or", []interface{}{
+				D{{"$nor", []interface{}{
+					D{{"published", D{{"$gte", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}}}},
+				}}},
+				D{{"users.type", D{{"$ne", "dogs"}}}},
+			}}},
+		},
+		{
+			`title:"C++ Primer"`,
+			D{{"title", D{{"$regex", `\bC\+\+ Primer\b`}, {"$options", "i"}}}},
+		},
+		{
+			`title:"C++"`,
+			D{{"title", D{{"$regex", `\bC\+\+`}, {"$options", "i"}}}},
+		},
+		{
+			`title:"東京" OR title:"#go"`,
+			D{{"$or", []interface{}{
+				D{{"title", D{{"$regex", `東京`}, {"$options", "i"}}}},
+				D{{"title", D{{"$regex", `#go\b`}, {"$options", "i"}}}},
+			}}},
+		},
+		{
+			`"say \"hi\" -x"`,
+			D{{"$text", D{{"$search", `"say  hi  -x"`}}}},
+		},
+		{
+			`blue guitar`,
+			D{{"$text", D{{"$search", `"blue" "guitar"`}}}},
+		},
+		{
+			`blue pages < 500 (guitar AND used = true)`,
+			D{{"$and", []interface{}{
+				D{{"$text", D{{"$search", `"blue" "guitar"`}}}},
+				D{{"pages", D{{"$lt", int64(500)}}}},
+				D{{"used", D{{"$eq", true}}}},
+			}}},
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		d, err := (&Translator{}).Translate(expr)
+		if assert.NoError(t, err, c.Query) {
+			assert.Equal(t, c.Expected, d, c.Query)
+		}
+	}
+
+	t.Run("keyword fields", func(t *testing.T) {
+		d, err := (&Translator{KeywordFields: []string{"title", "body"}}).Translate(&ast.Not{
+			Expr: &ast.KeywordExpr{Value: ast.StringValue("guitar")},
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]interface{}{
+				"$nor": []interface{}{
+					map[string]interface{}{
+						"$or": []interface{}{
+							map[string]interface{}{"title": map[string]interface{}{"$regex": `\bguitar\b`, "$options": "i"}},
+							map[string]interface{}{"body": map[string]interface{}{"$regex": `\bguitar\b`, "$options": "i"}},
+						},
+					},
+				},
+			}, d.Map())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			&ast.ColonExpr{Property: "title", Expr: &ast.KeywordExpr{Value: ast.StringValue("dog"), Stem: true}},
+			&ast.KeywordExpr{Value: ast.StringValue("dog"), Stem: true},
+			ast.And{},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+			&ast.OperatorExpr{Property: "", Operator: ast.OpEq, Value: ast.IntegerValue(1)},
+			ast.Or{&ast.KeywordExpr{Value: ast.StringValue("blue")}, &ast.KeywordExpr{Value: ast.StringValue("red")}},
+			&ast.Not{Expr: &ast.KeywordExpr{Value: ast.StringValue("blue")}},
+		} {
+			_, err := (&Translator{}).Translate(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
@@ -0,0 +1,421 @@
+package mongoquery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// ParseJSON returns the expression of the filter document b in JSON, see Parse.
+// Keys are kept in order, and {"$date": ...} of Extended JSON is read as a time value.
+func ParseJSON(b []byte) (ast.Expr, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	v, err := decode(dec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", pkgName, err)
+	}
+	if _, err := dec.Token(); err == nil {
+		return nil, fmt.Errorf("%s: trailing data after filter", pkgName)
+	}
+	return Parse(v)
+}
+
+// Parse returns the expression of the filter document, which is D or map[string]interface{}.
+// Keys of a map are read in sorted order.
+//
+// Comparisons, $in, $nin, $not, $and, $or, $nor, $text, and regular expressions of words as Translator
+// builds them are accepted. Other operators have no equivalent and are errors.
+func Parse(filter interface{}) (ast.Expr, error) {
+	d, ok := document(filter)
+	if !ok {
+		return nil, fmt.Errorf("%s: filter is not a document: %T", pkgName, filter)
+	}
+	if len(d) == 0 {
+		return nil, fmt.Errorf("%s: empty filter matching all documents has no equivalent", pkgName)
+	}
+	return parseDocument(d)
+}
+
+func parseDocument(d D) (ast.Expr, error) {
+	var exprs []ast.Expr
+	for _, e := range d {
+		var (
+			expr ast.Expr
+			err  error
+		)
+		switch e.Key {
+		case "$and", "$or", "$nor":
+			var list []ast.Expr
+			list, err = parseList(e.Key, e.Value)
+			switch e.Key {
+			case "$and":
+				expr = ast.And(list)
+			case "$or":
+				expr = ast.Or(list)
+			case "$nor":
+				expr = &ast.Not{Expr: unwrap(ast.Or(list))}
+			}
+		case "$text":
+			expr, err = parseText(e.Value)
+		default:
+			if strings.HasPrefix(e.Key, "$") {
+				return nil, fmt.Errorf("%s: unsupported operator %s", pkgName, e.Key)
+			}
+			expr, err = parseField(e.Key, e.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, unwrap(expr))
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return ast.And(exprs), nil
+}
+
+// parseList parses the documents of $and, $or or $nor.
+func parseList(op string, v interface{}) ([]ast.Expr, error) {
+	a, ok := v.([]interface{})
+	if !ok || len(a) == 0 {
+		return nil, fmt.Errorf("%s: %s must be a non-empty array", pkgName, op)
+	}
+	var exprs []ast.Expr
+	for _, v := range a {
+		d, ok := document(v)
+		if !ok || len(d) == 0 {
+			return nil, fmt.Errorf("%s: %s must hold non-empty documents", pkgName, op)
+		}
+		expr, err := parseDocument(d)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// unwrap returns the only element of And or Or.
+func unwrap(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case ast.And:
+		if len(e) == 1 {
+			return e[0]
+		}
+	case ast.Or:
+		if len(e) == 1 {
+			return e[0]
+		}
+	}
+	return expr
+}
+
+// parseField parses the condition of field, either a value or a document of operators.
+func parseField(field string, v interface{}) (ast.Expr, error) {
+	d, ok := document(v)
+	if !ok || isDate(d) {
+		value, err := parseValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: field %q: %v", pkgName, field, err)
+		}
+		return &ast.OperatorExpr{Property: field, Operator: ast.OpEq, Value: value}, nil
+	}
+	if len(d) == 0 {
+		return nil, fmt.Errorf("%s: field %q: empty document", pkgName, field)
+	}
+	var (
+		exprs   ast.And
+		options interface{}
+		pattern interface{}
+	)
+	for _, e := range d {
+		if !strings.HasPrefix(e.Key, "$") {
+			return nil, fmt.Errorf("%s: field %q: equality of embedded documents has no equivalent", pkgName, field)
+		}
+		switch e.Key {
+		case "$regex":
+			pattern = e.Value
+			continue
+		case "$options":
+			options = e.Value
+			continue
+		}
+		expr, err := parseOperator(field, e.Key, e.Value)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	if pattern != nil {
+		expr, err := parseRegex(field, pattern, options)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	} else if options != nil {
+		return nil, fmt.Errorf("%s: field %q: $options without $regex", pkgName, field)
+	}
+	return unwrap(exprs), nil
+}
+
+func parseOperator(field string, op string, v interface{}) (ast.Expr, error) {
+	if o, ok := ops[op]; ok {
+		value, err := parseValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: field %q: %s: %v", pkgName, field, op, err)
+		}
+		return &ast.OperatorExpr{Property: field, Operator: o, Value: value}, nil
+	}
+	switch op {
+	case "$in", "$nin":
+		a, ok := v.([]interface{})
+		if !ok || len(a) == 0 {
+			return nil, fmt.Errorf("%s: field %q: %s must be a non-empty array", pkgName, field, op)
+		}
+		o := ast.OpEq
+		if op == "$nin" {
+			o = ast.OpNeq
+		}
+		var exprs []ast.Expr
+		for _, v := range a {
+			value, err := parseValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: field %q: %s: %v", pkgName, field, op, err)
+			}
+			exprs = append(exprs, &ast.OperatorExpr{Property: field, Operator: o, Value: value})
+		}
+		if op == "$nin" {
+			return unwrap(ast.And(exprs)), nil
+		}
+		return unwrap(ast.Or(exprs)), nil
+	case "$not":
+		d, ok := document(v)
+		if !ok || isDate(d) {
+			return nil, fmt.Errorf("%s: field %q: $not must be a document of operators", pkgName, field)
+		}
+		expr, err := parseField(field, d)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Not{Expr: expr}, nil
+	default:
+		return nil, fmt.Errorf("%s: field %q: unsupported operator %s", pkgName, field, op)
+	}
+}
+
+var ops = map[string]ast.Op{}
+
+func init() {
+	for op, s := range operators {
+		ops[s] = op
+	}
+}
+
+// parseRegex parses a case insensitive match of whole words, which is the only regular expression with an
+// equivalent.
+func parseRegex(field string, pattern, options interface{}) (ast.Expr, error) {
+	p, ok := pattern.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s: field %q: $regex must be a string", pkgName, field)
+	}
+	quoted := strings.TrimSuffix(strings.TrimPrefix(p, `\b`), `\b`)
+	var b strings.Builder
+	for i := 0; i < len(quoted); i++ {
+		if quoted[i] == '\\' && i+1 < len(quoted) {
+			i++
+		}
+		b.WriteByte(quoted[i])
+	}
+	s := b.String()
+	if o, _ := options.(string); o != "i" || s == "" || wordPattern(s) != p {
+		return nil, fmt.Errorf("%s: field %q: regular expression %q has no equivalent", pkgName, field, p)
+	}
+	return &ast.ColonExpr{
+		Property: field,
+		Expr:     &ast.KeywordExpr{Value: ast.StringValue(s)},
+	}, nil
+}
+
+// parseText parses the $search string of $text, where phrases and negated terms are required and at least
+// one of other terms is.
+func parseText(v interface{}) (ast.Expr, error) {
+	d, ok := document(v)
+	if !ok {
+		return nil, fmt.Errorf("%s: $text must be a document", pkgName)
+	}
+	var search string
+	for _, e := range d {
+		switch e.Key {
+		case "$search":
+			s, ok := e.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: $text: $search must be a string", pkgName)
+			}
+			search = s
+		case "$caseSensitive", "$diacriticSensitive":
+			if b, _ := e.Value.(bool); b {
+				return nil, fmt.Errorf("%s: $text: %s has no equivalent", pkgName, e.Key)
+			}
+		case "$language":
+		default:
+			return nil, fmt.Errorf("%s: $text: unsupported field %s", pkgName, e.Key)
+		}
+	}
+	var (
+		exprs ast.And
+		terms ast.Or
+		nots  []ast.Expr
+	)
+	for s := strings.TrimSpace(search); s != ""; s = strings.TrimSpace(s) {
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("%s: $text: unterminated phrase", pkgName)
+			}
+			if phrase := strings.TrimSpace(s[1 : end+1]); phrase != "" {
+				exprs = append(exprs, &ast.KeywordExpr{Value: ast.StringValue(phrase)})
+			}
+			s = s[end+2:]
+			continue
+		}
+		word := s
+		if i := strings.IndexFunc(s, isSpace); i >= 0 {
+			word = s[:i]
+		}
+		s = s[len(word):]
+		if strings.HasPrefix(word, "-") && len(word) > 1 {
+			nots = append(nots, &ast.Not{Expr: &ast.KeywordExpr{Value: ast.StringValue(word[1:])}})
+		} else {
+			terms = append(terms, &ast.KeywordExpr{Value: ast.StringValue(word)})
+		}
+	}
+	if len(terms) > 0 {
+		exprs = append(exprs, unwrap(terms))
+	}
+	exprs = append(exprs, nots...)
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("%s: $text: empty $search", pkgName)
+	}
+	return unwrap(exprs), nil
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+func parseValue(v interface{}) (ast.Value, error) {
+	switch v := v.(type) {
+	case string:
+		return ast.StringValue(v), nil
+	case bool:
+		return ast.BoolValue(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return ast.IntegerValue(i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return ast.FloatValue(f), nil
+	case int:
+		return ast.IntegerValue(v), nil
+	case int32:
+		return ast.IntegerValue(v), nil
+	case int64:
+		return ast.IntegerValue(v), nil
+	case float32:
+		return ast.FloatValue(v), nil
+	case float64:
+		return ast.FloatValue(v), nil
+	case time.Time:
+		return ast.TimeValue(v), nil
+	}
+	if d, ok := document(v); ok && isDate(d) {
+		switch date := d[0].Value.(type) {
+		case string:
+			t, err := time.Parse(time.RFC3339, date)
+			if err != nil {
+				return nil, err
+			}
+			return ast.TimeValue(t), nil
+		case json.Number:
+			ms, err := date.Int64()
+			if err != nil {
+				return nil, err
+			}
+			return ast.TimeValue(time.Unix(0, ms*int64(time.Millisecond)).UTC()), nil
+		}
+	}
+	return nil, fmt.Errorf("value %v has no equivalent", v)
+}
+
+// isDate reports whether d is {"$date": ...} of Extended JSON.
+func isDate(d D) bool {
+	return len(d) == 1 && d[0].Key == "$date"
+}
+
+// document converts v into D if v is a document.
+func document(v interface{}) (D, bool) {
+	switch v := v.(type) {
+	case D:
+		return v, true
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		d := make(D, 0, len(v))
+		for _, k := range keys {
+			d = append(d, E{Key: k, Value: v[k]})
+		}
+		return d, true
+	default:
+		return nil, false
+	}
+}
+
+// decode decodes the next JSON value, keeping the order of keys in objects as D.
+func decode(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		d := D{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			d = append(d, E{Key: key.(string), Value: v})
+		}
+		_, err := dec.Token()
+		return d, err
+	case json.Delim('['):
+		a := []interface{}{}
+		for dec.More() {
+			v, err := decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		_, err := dec.Token()
+		return a, err
+	default:
+		return tok, nil
+	}
+}
@@ -0,0 +1,93 @@
+package mongoquery
+
+import (
+	"testing"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSON(t *testing.T) {
+	cases := []struct {
+		Filter   string
+		Expected string
+	}{
+		{`{"pages": {"$lt": 500}}`, `pages < 500`},
+		{`{"title": "potter", "price": 9.5, "available": true}`, `title = potter price = 9.5 available = true`},
+		{`{"pages": {"$gte": 2, "$lte": 5}}`, `pages >= 2 AND pages <= 5`},
+		{`{"$or": [{"a": 1}, {"b": 2}], "$nor": [{"c": 3}]}`, `(a = 1 OR b = 2) AND NOT c = 3`},
+		{`{"color": {"$in": ["red", "white"]}, "country": {"$nin": ["france"]}}`, `(color = red OR color = white) country != france`},
+		{`{"pages": {"$not": {"$gt": 5}}}`, `NOT pages > 5`},
+		{`{"title": {"$regex": "\\bharry potter\\b", "$options": "i"}}`, `title:"harry potter"`},
+		{`{"$text": {"$search": "\"harry potter\" wizard witch -muggle"}}`, `"harry potter" (wizard OR witch) NOT muggle`},
+		{`{"created": {"$gt": {"$date": "2020-01-02T03:04:05Z"}}}`, `created > 2020-01-02T03:04:05Z`},
+	}
+	for _, c := range cases {
+		expr, err := ParseJSON([]byte(c.Filter))
+		if !assert.NoError(t, err, c.Filter) {
+			continue
+		}
+		expected, err := searchquery.Parse(c.Expected)
+		if assert.NoError(t, err, c.Expected) {
+			assert.Equal(t, expected, expr, c.Filter)
+		}
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, q := range []string{
+			`users.type != dogs AND users.type = horses`,
+			`NOT title:"harry potter" OR pages >= 500 AND color:red`,
+			`harry`,
+			`title:"C++" OR title:"東京" OR title:"#go"`,
+			`blue guitar`,
+		} {
+			expr, err := searchquery.Parse(q)
+			if !assert.NoError(t, err, q) {
+				continue
+			}
+			d, err := (&Translator{}).Translate(expr)
+			if !assert.NoError(t, err, q) {
+				continue
+			}
+			actual, err := Parse(d)
+			if assert.NoError(t, err, q) {
+				assert.Equal(t, expr, actual, q)
+			}
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		expr, err := Parse(map[string]interface{}{
+			"b": map[string]interface{}{"$gt": time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+			"a": 1,
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, ast.And{
+				&ast.OperatorExpr{Property: "a", Operator: ast.OpEq, Value: ast.IntegerValue(1)},
+				&ast.OperatorExpr{Property: "b", Operator: ast.OpGt, Value: ast.TimeValue(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))},
+			}, expr)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, f := range []string{
+			`{}`,
+			`[]`,
+			`{"a": 1} {}`,
+			`{"$where": "x"}`,
+			`{"a": {"$exists": true}}`,
+			`{"a": {"b": 1}}`,
+			`{"a": null}`,
+			`{"a": [1]}`,
+			`{"a": {"$regex": "^x"}}`,
+			`{"a": {"$regex": "\\bx\\b"}}`,
+			`{"$or": []}`,
+			`{"$text": {"$search": "x", "$caseSensitive": true}}`,
+		} {
+			_, err := ParseJSON([]byte(f))
+			assert.Error(t, err, f)
+		}
+	})
+}
@@ -0,0 +1,258 @@
+// Package mysqlquery translates query expressions into MySQL WHERE clauses.
+//
+// Comparisons become ordinary SQL comparisons, and keyword searches become full text searches in boolean mode.
+// Keywords combined by AND, OR and NOT are searched by a single MATCH where boolean mode can express them:
+//
+//	MATCH(`title`) AGAINST(? IN BOOLEAN MODE)  -- with the argument `+"harry" +"potter"`
+package mysqlquery
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/mysqlquery"
+)
+
+type Translator struct {
+	// Columns maps properties to SQL expressions. Properties not in Columns are used as quoted identifiers,
+	// e.g. users.name becomes `users`.`name`.
+	Columns map[string]string
+
+	// KeywordColumns are the columns searched by keywords outside of property:expr, they must be covered by
+	// a FULLTEXT index. A keyword is an error if KeywordColumns is empty.
+	KeywordColumns []string
+}
+
+// Translate returns a WHERE clause with ? placeholders and its arguments.
+func (t *Translator) Translate(expr ast.Expr) (string, []interface{}, error) {
+	tr := &translation{Translator: t}
+	var b strings.Builder
+	if err := tr.expr(&b, expr, nil); err != nil {
+		return "", nil, err
+	}
+	return b.String(), tr.args, nil
+}
+
+type translation struct {
+	*Translator
+
+	args []interface{}
+}
+
+// expr writes expr, textColumns is non-nil inside of property:expr.
+func (t *translation) expr(b *strings.Builder, expr ast.Expr, textColumns []string) error {
+	switch e := expr.(type) {
+	case ast.And:
+		if s, ok, err := booleanQuery(expr, false); err != nil {
+			return err
+		} else if ok {
+			return t.match(b, s, textColumns)
+		}
+		return t.join(b, []ast.Expr(e), " AND ", textColumns)
+	case ast.Or:
+		if s, ok, err := booleanQuery(expr, false); err != nil {
+			return err
+		} else if ok {
+			return t.match(b, s, textColumns)
+		}
+		return t.join(b, []ast.Expr(e), " OR ", textColumns)
+	case *ast.Not:
+		b.WriteString("NOT (")
+		if err := t.expr(b, e.Expr, textColumns); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case *ast.OperatorExpr:
+		column, err := t.column(e.Property)
+		if err != nil {
+			return err
+		}
+		op, err := operator(e.Operator)
+		if err != nil {
+			return err
+		}
+		if e.Value == nil {
+			return fmt.Errorf("%s: property %q: nil value", pkgName, e.Property)
+		}
+		b.WriteString(column + " " + op + " ?")
+		t.args = append(t.args, e.Value.Raw())
+	case *ast.ColonExpr:
+		column, err := t.column(e.Property)
+		if err != nil {
+			return err
+		}
+		return t.expr(b, e.Expr, []string{column})
+	case *ast.KeywordExpr:
+		s, _, err := booleanQuery(expr, false)
+		if err != nil {
+			return err
+		}
+		return t.match(b, s, textColumns)
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func (t *translation) join(b *strings.Builder, exprs []ast.Expr, sep string, textColumns []string) error {
+	if len(exprs) == 0 {
+		return fmt.Errorf("%s: empty%sexpression", pkgName, strings.ToLower(sep))
+	}
+	b.WriteString("(")
+	for i, v := range exprs {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		if err := t.expr(b, v, textColumns); err != nil {
+			return err
+		}
+	}
+	b.WriteString(")")
+	return nil
+}
+
+func (t *translation) match(b *strings.Builder, query string, columns []string) error {
+	if columns == nil {
+		if len(t.KeywordColumns) == 0 {
+			return fmt.Errorf("%s: no keyword columns configured", pkgName)
+		}
+		columns = t.KeywordColumns
+	}
+	b.WriteString("MATCH(" + strings.Join(columns, ", ") + ") AGAINST(? IN BOOLEAN MODE)")
+	t.args = append(t.args, query)
+	return nil
+}
+
+// booleanQuery builds a boolean mode search string from keywords combined by AND, OR and NOT.
+// It reports false if expr contains anything else, or boolean mode can not express it, e.g. "a OR NOT b".
+func booleanQuery(expr ast.Expr, nested bool) (string, bool, error) {
+	switch e := expr.(type) {
+	case *ast.KeywordExpr:
+		var (
+			s   string
+			err error
+		)
+		if e.Stem {
+			s, err = stemTerm(e.Value)
+		} else {
+			s, err = term(e.Value)
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return s, true, nil
+	case ast.And:
+		var (
+			parts    []string
+			positive bool
+		)
+		for _, v := range e {
+			prefix := "+"
+			if not, ok := v.(*ast.Not); ok {
+				prefix, v = "-", not.Expr
+			} else {
+				positive = true
+			}
+			s, ok, err := booleanQuery(v, true)
+			if err != nil || !ok {
+				return "", false, err
+			}
+			parts = append(parts, prefix+s)
+		}
+		// only excluding terms match nothing in boolean mode
+		if !positive {
+			return "", false, nil
+		}
+		return group(parts, nested), true, nil
+	case ast.Or:
+		if len(e) == 0 {
+			return "", false, nil
+		}
+		var parts []string
+		for _, v := range e {
+			if _, ok := v.(*ast.Not); ok {
+				return "", false, nil
+			}
+			s, ok, err := booleanQuery(v, true)
+			if err != nil || !ok {
+				return "", false, err
+			}
+			parts = append(parts, s)
+		}
+		return group(parts, nested), true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+func group(parts []string, nested bool) string {
+	s := strings.Join(parts, " ")
+	if nested && len(parts) > 1 {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// term quotes a keyword as a phrase, which makes boolean mode operators in it literal.
+// Double quotes can not be escaped in boolean mode, so they are removed.
+func term(v ast.Value) (string, error) {
+	s, err := ast.AsString(v)
+	if err != nil {
+		return "", err
+	}
+	s = strings.TrimSpace(strings.Replace(s, `"`, " ", -1))
+	if s == "" {
+		return "", fmt.Errorf("%s: empty keyword", pkgName)
+	}
+	return `"` + s + `"`, nil
+}
+
+// stemTerm returns the truncation of the Porter stem of a word, e.g. run* for ~running, which matches the words
+// sharing the stem.
+func stemTerm(v ast.Value) (string, error) {
+	s, err := ast.AsString(v)
+	if err != nil {
+		return "", err
+	}
+	if s == "" || strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) >= 0 {
+		return "", fmt.Errorf("%s: stemmed keyword ~%q is not a word", pkgName, s)
+	}
+	return analysis.Stem(strings.ToLower(s)) + "*", nil
+}
+
+func (t *translation) column(property string) (string, error) {
+	if column, ok := t.Columns[property]; ok {
+		return column, nil
+	}
+	if property == "" {
+		return "", fmt.Errorf("%s: empty property", pkgName)
+	}
+	var names []string
+	for _, name := range strings.Split(property, ".") {
+		names = append(names, quoteIdent(name))
+	}
+	return strings.Join(names, "."), nil
+}
+
+func operator(op ast.Op) (string, error) {
+	switch op {
+	case ast.OpEq:
+		return "=", nil
+	case ast.OpNeq:
+		return "<>", nil
+	case ast.OpLt, ast.OpLe, ast.OpGt, ast.OpGe:
+		return op.String(), nil
+	default:
+		return "", fmt.Errorf("%s: invalid operator %v", pkgName, op)
+	}
+}
+
+func quoteIdent(s string) string {
+	return "`" + strings.Replace(s, "`", "``", -1) + "`"
+}
@@ -0,0 +1,94 @@
+package mysqlquery
+
+import (
+	"testing"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	tr := &Translator{
+		Columns: map[string]string{
+			"title": "books.title",
+		},
+		KeywordColumns: []string{"books.title", "books.body"},
+	}
+	cases := []struct {
+		Query string
+		SQL   string
+		Args  []interface{}
+	}{
+		{
+			`NOT users.name = kamichidu OR users.type != dogs AND pages >= 500`,
+			"((NOT (`users`.`name` = ?) OR `users`.`type` <> ?) AND `pages` >= ?)",
+			[]interface{}{"kamichidu", "dogs", int64(500)},
+		},
+		{
+			`title:"Harry Potter" AND published < 2000-01-01`,
+			"(MATCH(books.title) AGAINST(? IN BOOLEAN MODE) AND `published` < ?)",
+			[]interface{}{`"Harry Potter"`, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			`blue guitar NOT fender`,
+			"MATCH(books.title, books.body) AGAINST(? IN BOOLEAN MODE)",
+			[]interface{}{`+"blue" +"guitar" -"fender"`},
+		},
+		{
+			`color:(red OR white) NOT country:france`,
+			"(MATCH(`color`) AGAINST(? IN BOOLEAN MODE) AND NOT (MATCH(`country`) AGAINST(? IN BOOLEAN MODE)))",
+			[]interface{}{`"red" "white"`, `"france"`},
+		},
+		{
+			`guitar (gibson OR fender)`,
+			"MATCH(books.title, books.body) AGAINST(? IN BOOLEAN MODE)",
+			[]interface{}{`+"guitar" +("gibson" "fender")`},
+		},
+		{
+			`red OR NOT white`,
+			"(MATCH(books.title, books.body) AGAINST(? IN BOOLEAN MODE) OR NOT (MATCH(books.title, books.body) AGAINST(? IN BOOLEAN MODE)))",
+			[]interface{}{`"red"`, `"white"`},
+		},
+		{
+			`~running "big dogs"`,
+			"MATCH(books.title, books.body) AGAINST(? IN BOOLEAN MODE)",
+			[]interface{}{`+run* +"big dogs"`},
+		},
+		{
+			`"+5* ~(x)" "say \"hi\""`,
+			"MATCH(books.title, books.body) AGAINST(? IN BOOLEAN MODE)",
+			[]interface{}{`+"+5* ~(x)" +"say  hi"`},
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		sql, args, err := tr.Translate(expr)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		assert.Equal(t, c.SQL, sql, c.Query)
+		assert.Equal(t, c.Args, args, c.Query)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			&ast.ColonExpr{Property: "a", Expr: &ast.KeywordExpr{Value: ast.StringValue(`"`)}},
+			&ast.KeywordExpr{Value: ast.StringValue("big dogs"), Stem: true},
+			ast.Or{},
+			ast.And{},
+		} {
+			_, _, err := (&Translator{KeywordColumns: []string{"body"}}).Translate(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+
+	t.Run("no keyword columns", func(t *testing.T) {
+		_, _, err := (&Translator{}).Translate(&ast.KeywordExpr{Value: ast.StringValue("x")})
+		assert.Error(t, err)
+	})
+}
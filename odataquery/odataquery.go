@@ -0,0 +1,187 @@
+// Package odataquery prints query expressions as OData v4 $filter expressions, e.g.
+//
+//	price lt 500 and contains(title,'Harry')
+package odataquery
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/odataquery"
+)
+
+type Printer struct {
+	// KeywordFields are the string properties searched by keywords outside of property:expr.
+	// A keyword is an error if KeywordFields is empty, such searches belong to $search in OData.
+	KeywordFields []string
+}
+
+// Print returns the $filter expression for expr.
+// Text searches of strings become contains calls, and properties such as users.name become paths users/name.
+func (p *Printer) Print(expr ast.Expr) (string, error) {
+	var b strings.Builder
+	if err := p.expr(&b, expr, ""); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// expr writes expr, field is non-empty inside of property:expr.
+func (p *Printer) expr(b *strings.Builder, expr ast.Expr, field string) error {
+	switch e := expr.(type) {
+	case ast.And:
+		return p.join(b, []ast.Expr(e), " and ", field)
+	case ast.Or:
+		return p.join(b, []ast.Expr(e), " or ", field)
+	case *ast.Not:
+		b.WriteString("not (")
+		if err := p.expr(b, e.Expr, field); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case *ast.OperatorExpr:
+		path, err := propertyPath(e.Property)
+		if err != nil {
+			return err
+		}
+		op, ok := operators[e.Operator]
+		if !ok {
+			return fmt.Errorf("%s: invalid operator %v", pkgName, e.Operator)
+		}
+		v, err := literal(e.Value)
+		if err != nil {
+			return err
+		}
+		b.WriteString(path + " " + op + " " + v)
+	case *ast.ColonExpr:
+		path, err := propertyPath(e.Property)
+		if err != nil {
+			return err
+		}
+		return p.expr(b, e.Expr, path)
+	case *ast.KeywordExpr:
+		if e.Stem {
+			return fmt.Errorf("%s: stemmed keyword ~%v has no $filter equivalent", pkgName, e.Value)
+		}
+		if field != "" {
+			return keyword(b, field, e.Value)
+		}
+		if len(p.KeywordFields) == 0 {
+			return fmt.Errorf("%s: keyword %v has no $filter equivalent without keyword fields", pkgName, e.Value)
+		}
+		if len(p.KeywordFields) > 1 {
+			b.WriteString("(")
+		}
+		for i, f := range p.KeywordFields {
+			if i > 0 {
+				b.WriteString(" or ")
+			}
+			path, err := propertyPath(f)
+			if err != nil {
+				return err
+			}
+			if err := keyword(b, path, e.Value); err != nil {
+				return err
+			}
+		}
+		if len(p.KeywordFields) > 1 {
+			b.WriteString(")")
+		}
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func (p *Printer) join(b *strings.Builder, exprs []ast.Expr, sep string, field string) error {
+	if len(exprs) == 0 {
+		return fmt.Errorf("%s: empty%sexpression", pkgName, sep)
+	}
+	for i, v := range exprs {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		compound := isCompound(v)
+		if compound {
+			b.WriteString("(")
+		}
+		if err := p.expr(b, v, field); err != nil {
+			return err
+		}
+		if compound {
+			b.WriteString(")")
+		}
+	}
+	return nil
+}
+
+// isCompound reports whether expr is printed as several terms joined by and or or.
+func isCompound(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case ast.And, ast.Or:
+		return true
+	case *ast.ColonExpr:
+		return isCompound(e.Expr)
+	default:
+		return false
+	}
+}
+
+// keyword writes a search of v in field, strings are searched as substrings and others are compared.
+func keyword(b *strings.Builder, path string, v ast.Value) error {
+	s, err := literal(v)
+	if err != nil {
+		return err
+	}
+	if _, ok := v.(ast.StringValue); ok {
+		b.WriteString("contains(" + path + "," + s + ")")
+	} else {
+		b.WriteString(path + " eq " + s)
+	}
+	return nil
+}
+
+var operators = map[ast.Op]string{
+	ast.OpEq:  "eq",
+	ast.OpNeq: "ne",
+	ast.OpLt:  "lt",
+	ast.OpLe:  "le",
+	ast.OpGt:  "gt",
+	ast.OpGe:  "ge",
+}
+
+func propertyPath(property string) (string, error) {
+	if property == "" {
+		return "", fmt.Errorf("%s: empty property", pkgName)
+	}
+	return strings.Replace(property, ".", "/", -1), nil
+}
+
+func literal(v ast.Value) (string, error) {
+	switch v := v.(type) {
+	case ast.StringValue:
+		return "'" + strings.Replace(string(v), "'", "''", -1) + "'", nil
+	case ast.IntegerValue:
+		return strconv.FormatInt(int64(v), 10), nil
+	case ast.FloatValue:
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("%s: unrepresentable float value %v", pkgName, f)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case ast.BoolValue:
+		return strconv.FormatBool(bool(v)), nil
+	case ast.TimeValue:
+		// Edm.DateTimeOffset
+		return time.Time(v).Format(time.RFC3339Nano), nil
+	default:
+		return "", fmt.Errorf("%s: unknown value type %T", pkgName, v)
+	}
+}
@@ -0,0 +1,62 @@
+package odataquery
+
+import (
+	"math"
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter_Print(t *testing.T) {
+	p := &Printer{KeywordFields: []string{"title", "body"}}
+	cases := []struct {
+		Query    string
+		Expected string
+	}{
+		{
+			`price < 500 title:Harry`,
+			`price lt 500 and contains(title,'Harry')`,
+		},
+		{
+			`users.type != dogs AND users.type = "o'neil"`,
+			`users/type ne 'dogs' and users/type eq 'o''neil'`,
+		},
+		{
+			`NOT cat OR dogs`,
+			`not ((contains(title,'cat') or contains(body,'cat'))) or (contains(title,'dogs') or contains(body,'dogs'))`,
+		},
+		{
+			`color:(red OR white) NOT country:france pages:500`,
+			`(contains(color,'red') or contains(color,'white')) and not (contains(country,'france')) and pages eq 500`,
+		},
+		{
+			`create_time > 2020-01-02T03:04:05Z price <= 9.5 pages >= 2 available = true`,
+			`create_time gt 2020-01-02T03:04:05Z and price le 9.5 and pages ge 2 and available eq true`,
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		s, err := p.Print(expr)
+		if assert.NoError(t, err, c.Query) {
+			assert.Equal(t, c.Expected, s, c.Query)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expr := range []ast.Expr{
+			&ast.ColonExpr{Property: "title", Expr: &ast.KeywordExpr{Value: ast.StringValue("dog"), Stem: true}},
+			ast.And{},
+			&ast.KeywordExpr{Value: ast.StringValue("x")},
+			&ast.OperatorExpr{Property: "a", Operator: ast.Op(0), Value: ast.IntegerValue(1)},
+			&ast.OperatorExpr{Property: "a", Operator: ast.OpEq, Value: ast.FloatValue(math.NaN())},
+		} {
+			_, err := (&Printer{}).Print(expr)
+			assert.Error(t, err, "%#v", expr)
+		}
+	})
+}
@@ -9,13 +9,10 @@ const (
 )
 
 func Parse(s string) (ast.Expr, error) {
-	var q Query
-	q.Buffer = s
-	q.Init()
-	if err := q.Parse(); err != nil {
-		return nil, err
-	}
-	q.Execute()
-	// q.PrintSyntaxTree()
-	return q.Expr, nil
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is a shorthand for NewParser(opts...).Parse(s).
+func ParseWithOptions(s string, opts ...ParseOption) (ast.Expr, error) {
+	return NewParser(opts...).Parse(s)
 }
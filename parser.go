@@ -0,0 +1,220 @@
+package searchquery
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+type Mode int
+
+const (
+	// ModeStrict reports malformed queries as errors.
+	ModeStrict Mode = iota
+
+	// ModeLenient searches malformed queries as plain keywords, as a search box would.
+	ModeLenient
+)
+
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	limits limits
+
+	mode Mode
+
+	location *time.Location
+
+	schema *Schema
+
+	logger *log.Logger
+}
+
+// WithMode sets how malformed queries are handled, ModeStrict by default.
+func WithMode(mode Mode) ParseOption {
+	return func(c *parseConfig) {
+		c.mode = mode
+	}
+}
+
+// WithTimeZone sets the time zone of date literals such as 2006-01-02, UTC by default.
+func WithTimeZone(loc *time.Location) ParseOption {
+	return func(c *parseConfig) {
+		c.location = loc
+	}
+}
+
+// WithSchema checks parsed queries by schema.Check.
+func WithSchema(schema *Schema) ParseOption {
+	return func(c *parseConfig) {
+		c.schema = schema
+	}
+}
+
+// WithLogger writes a trace of parsing into logger.
+func WithLogger(logger *log.Logger) ParseOption {
+	return func(c *parseConfig) {
+		c.logger = logger
+	}
+}
+
+// SyntaxError is reported for a query which does not follow the grammar.
+type SyntaxError struct {
+	// Offset is the byte offset in the query where parsing failed.
+	Offset int
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: syntax error at offset %d", pkgName, e.Offset)
+}
+
+// ValueError is reported for a value which follows the grammar but can not be represented, such as the date
+// 2020-13-45 or an integer out of the range of int64.
+type ValueError struct {
+	Value string
+
+	// Offset is the byte offset of Value in the query.
+	Offset int
+
+	Err error
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("%s: invalid value %s at offset %d: %v", pkgName, e.Value, e.Offset, e.Err)
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// Parser parses queries with fixed options.
+// A Parser is safe for concurrent use.
+type Parser struct {
+	cfg parseConfig
+}
+
+func NewParser(opts ...ParseOption) *Parser {
+	p := &Parser{}
+	p.cfg.location = time.UTC
+	for _, opt := range opts {
+		opt(&p.cfg)
+	}
+	return p
+}
+
+func (p *Parser) Parse(s string) (ast.Expr, error) {
+	expr, err := p.parse(s)
+	if err != nil && p.cfg.mode == ModeLenient && !errors.Is(err, ErrQueryTooComplex) {
+		p.log("falling back to keywords: %v", err)
+		if kwExpr, ok := p.parseKeywords(s); ok {
+			expr, err = kwExpr, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.cfg.schema != nil {
+		if err := p.cfg.schema.Check(expr); err != nil {
+			return nil, err
+		}
+	}
+	return expr, nil
+}
+
+func (p *Parser) parse(s string) (expr ast.Expr, err error) {
+	if max := p.cfg.limits.MaxInputBytes; max > 0 && len(s) > max {
+		return nil, &LimitError{Limit: LimitInputBytes, Max: max, Offset: max}
+	}
+	if max := p.cfg.limits.MaxDepth; max > 0 {
+		if err := scanDepth(s, max); err != nil {
+			return nil, err
+		}
+	}
+
+	var q Query
+	q.Buffer = s
+	q.limits = p.cfg.limits
+	q.location = p.cfg.location
+	q.logger = p.cfg.logger
+	q.Debug = p.cfg.logger != nil
+	q.Init()
+	if err := q.Parse(); err != nil {
+		if perr, ok := err.(*parseError); ok {
+			// the parser fails after the longest match, in runes
+			return nil, &SyntaxError{Offset: len(string(q.buffer[:perr.max.end]))}
+		}
+		return nil, err
+	}
+	if p.cfg.logger != nil {
+		logSyntaxTree(p.cfg.logger, q.AST(), 0, q.buffer)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			// the builder knows rune offsets only
+			switch e := r.(type) {
+			case *LimitError:
+				e.Offset = len(string(q.buffer[:e.Offset]))
+				expr, err = nil, e
+			case *ValueError:
+				e.Offset = len(string(q.buffer[:e.Offset]))
+				expr, err = nil, e
+			default:
+				panic(r)
+			}
+		}
+	}()
+	q.Execute()
+	return q.Expr, nil
+}
+
+// parseKeywords reads s as a list of words, ignoring any syntax.
+func (p *Parser) parseKeywords(s string) (ast.Expr, bool) {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var and ast.And
+	for _, word := range words {
+		switch word {
+		case "AND", "OR", "NOT":
+			continue
+		}
+		and = append(and, &ast.KeywordExpr{
+			Value: ast.StringValue(word),
+		})
+	}
+	nodes := len(and)
+	if nodes > 1 {
+		nodes++
+	}
+	if max := p.cfg.limits.MaxNodes; max > 0 && nodes > max {
+		return nil, false
+	}
+	switch len(and) {
+	case 0:
+		return nil, false
+	case 1:
+		return and[0], true
+	default:
+		return and, true
+	}
+}
+
+func (p *Parser) log(format string, args ...interface{}) {
+	if p.cfg.logger == nil {
+		return
+	}
+	p.cfg.logger.Printf("trace: "+format, args...)
+}
+
+func logSyntaxTree(logger *log.Logger, node *node32, depth int, buffer []rune) {
+	for ; node != nil; node = node.next {
+		logger.Printf("syntax: %s%v %s", strings.Repeat(" ", depth), rul3s[node.pegRule], strconv.Quote(string(buffer[node.begin:node.end])))
+		logSyntaxTree(logger, node.up, depth+1, buffer)
+	}
+}
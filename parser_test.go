@@ -0,0 +1,131 @@
+package searchquery
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser(t *testing.T) {
+	t.Run("strict", func(t *testing.T) {
+		_, err := NewParser().Parse(`(blue guitar`)
+		assert.Error(t, err)
+
+		for s, offset := range map[string]int{
+			`(blue guitar`:   12,
+			`pages < `:       8,
+			`"東京" AND 大阪`:    13,
+			`title:potter )`: 13,
+		} {
+			_, err := NewParser().Parse(s)
+			var serr *SyntaxError
+			if assert.True(t, errors.As(err, &serr), s) {
+				assert.Equal(t, offset, serr.Offset, s)
+			}
+		}
+	})
+	t.Run("invalid values", func(t *testing.T) {
+		for s, offset := range map[string]int{
+			`date < 2020-13-45`:                       7,
+			`"東京" n = 99999999999999999999`:           13,
+			`n > -99999999999999999999`:               4,
+			`t:2020-01-01T25:00:00Z`:                  2,
+			`f < 1` + strings.Repeat("0", 400) + `.5`: 4,
+		} {
+			_, err := NewParser().Parse(s)
+			var verr *ValueError
+			if assert.True(t, errors.As(err, &verr), "%s: %v", s, err) {
+				assert.Equal(t, offset, verr.Offset, s)
+			}
+		}
+		_, err := Parse(`n = 99999999999999999999`)
+		assert.True(t, errors.Is(err, strconv.ErrRange), "%v", err)
+		assert.EqualError(t, err, "searchquery: invalid value 99999999999999999999 at offset 4: value out of range")
+	})
+	t.Run("lenient", func(t *testing.T) {
+		p := NewParser(WithMode(ModeLenient))
+		expr, err := p.Parse(`(blue AND guitar`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ast.And{
+				&ast.KeywordExpr{Value: ast.StringValue("blue")},
+				&ast.KeywordExpr{Value: ast.StringValue("guitar")},
+			}, expr)
+		}
+		expr, err = p.Parse(`pages < 500`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &ast.OperatorExpr{Property: "pages", Operator: ast.OpLt, Value: ast.IntegerValue(500)}, expr)
+		}
+		expr, err = p.Parse(`date < 2020-13-45`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ast.And{
+				&ast.KeywordExpr{Value: ast.StringValue("date")},
+				&ast.KeywordExpr{Value: ast.StringValue("2020")},
+				&ast.KeywordExpr{Value: ast.StringValue("13")},
+				&ast.KeywordExpr{Value: ast.StringValue("45")},
+			}, expr)
+		}
+		expr, err = p.Parse(`n = 99999999999999999999`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ast.And{
+				&ast.KeywordExpr{Value: ast.StringValue("n")},
+				&ast.KeywordExpr{Value: ast.StringValue("99999999999999999999")},
+			}, expr)
+		}
+		_, err = p.Parse(`((`)
+		assert.Error(t, err)
+	})
+	t.Run("time zone", func(t *testing.T) {
+		jst := time.FixedZone("JST", 9*60*60)
+		expr, err := NewParser(WithTimeZone(jst)).Parse(`date < 1965-01-01 AND date > 1964-01-01T00:00:00Z`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ast.And{
+				&ast.OperatorExpr{Property: "date", Operator: ast.OpLt, Value: ast.TimeValue(time.Date(1965, 1, 1, 0, 0, 0, 0, jst))},
+				&ast.OperatorExpr{Property: "date", Operator: ast.OpGt, Value: ast.TimeValue(mustParseTime("1964-01-01T00:00:00Z"))},
+			}, expr)
+		}
+	})
+	t.Run("schema", func(t *testing.T) {
+		p := NewParser(WithSchema(&Schema{
+			Fields: []Field{
+				{Name: "pages", Kind: ast.KindInteger},
+			},
+		}))
+		_, err := p.Parse(`pages < 500`)
+		assert.NoError(t, err)
+		_, err = p.Parse(`title:potter`)
+		assert.IsType(t, &SchemaError{}, err)
+	})
+	t.Run("logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := NewParser(WithLogger(log.New(&buf, "", 0))).Parse(`NOT white`)
+		if assert.NoError(t, err) {
+			assert.Contains(t, buf.String(), "trace: pushNot")
+			assert.Contains(t, buf.String(), `syntax:   Expr "NOT white"`)
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		p := NewParser(WithMaxDepth(10))
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					expr, err := p.Parse(`beverage:wine color:(red OR white) NOT country:france`)
+					if assert.NoError(t, err) {
+						assert.Len(t, expr, 3)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,72 @@
+package searchquery
+
+// segment is a part of a query which the grammar accepts as the beginning of a query in partial mode.
+type segment struct {
+	// start and end are the byte offsets of the segment in the query.
+	start, end int
+
+	tokens []token32
+
+	// offsets are the byte offsets in the query of the runes of the segment and of its end, as offsets of
+	// tokens are in runes.
+	offsets []int
+}
+
+// parsePartial splits s into segments, each of which is the longest beginning of the rest of s accepted by
+// the grammar in partial mode. Segments are separated by a character which the grammar does not accept, or a
+// run of non-ASCII characters. The last segment ends at the end of s, and may be empty.
+func parsePartial(s string) []segment {
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(s))
+
+	q := &Query{Buffer: s}
+	q.partial = true
+	q.Init()
+	if err := q.Parse(); err != nil {
+		// the grammar accepts any text in partial mode
+		return []segment{{start: len(s), end: len(s), offsets: offsets}}
+	}
+	// tokens of a rule follow tokens of rules in it
+	var segments []segment
+	first := 0
+	for i, t := range q.tokens32.tree {
+		switch t.pegRule {
+		case ruleSegment:
+			segments = append(segments, segment{
+				start:   offsets[t.begin],
+				end:     offsets[t.end],
+				tokens:  q.tokens32.tree[first : i+1],
+				offsets: offsets,
+			})
+			first = i + 1
+		case ruleJunk:
+			first = i + 1
+		}
+	}
+	return segments
+}
+
+// text returns the text of t.
+func (s *segment) text(query string, t token32) string {
+	return query[s.offsets[t.begin]:s.offsets[t.end]]
+}
+
+// at returns tokens of rules which end at the byte offset end, inner ones first.
+func (s *segment) at(end int, rules ...pegRule) []token32 {
+	var tokens []token32
+	for _, t := range s.tokens {
+		if s.offsets[t.end] != end {
+			continue
+		}
+		for _, r := range rules {
+			if t.pegRule == r {
+				tokens = append(tokens, t)
+				break
+			}
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,194 @@
+// Package pgquery translates query expressions into PostgreSQL WHERE clauses.
+//
+// Comparisons become ordinary SQL comparisons, and keyword searches become full text searches:
+//
+//	to_tsvector('english', "title") @@ plainto_tsquery('english', $1)
+package pgquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/pgquery"
+)
+
+type Translator struct {
+	// Columns maps properties to SQL expressions. Properties not in Columns are used as quoted identifiers,
+	// e.g. users.name becomes "users"."name".
+	Columns map[string]string
+
+	// KeywordColumns are the columns searched by keywords outside of property:expr.
+	// A keyword is an error if KeywordColumns is empty.
+	KeywordColumns []string
+
+	// TextSearchConfig is the text search configuration such as "english".
+	// The server's default_text_search_config is used if empty. Stemmed keywords such as ~dog are searched as
+	// other keywords, which the configuration stems.
+	TextSearchConfig string
+
+	// PlaceholderOffset is the number of placeholders already used in the statement, so the first
+	// placeholder is $(PlaceholderOffset+1).
+	PlaceholderOffset int
+}
+
+// Translate returns a WHERE clause with $n placeholders and its arguments.
+func (t *Translator) Translate(expr ast.Expr) (string, []interface{}, error) {
+	tr := &translation{Translator: t}
+	var b strings.Builder
+	if err := tr.expr(&b, expr, nil); err != nil {
+		return "", nil, err
+	}
+	return b.String(), tr.args, nil
+}
+
+type translation struct {
+	*Translator
+
+	args []interface{}
+}
+
+func (t *translation) placeholder(v interface{}) string {
+	t.args = append(t.args, v)
+	return "$" + strconv.Itoa(t.PlaceholderOffset+len(t.args))
+}
+
+// expr writes expr, textColumns is non-nil inside of property:expr.
+func (t *translation) expr(b *strings.Builder, expr ast.Expr, textColumns []string) error {
+	switch e := expr.(type) {
+	case ast.And:
+		return t.join(b, []ast.Expr(e), " AND ", textColumns)
+	case ast.Or:
+		return t.join(b, []ast.Expr(e), " OR ", textColumns)
+	case *ast.Not:
+		b.WriteString("NOT (")
+		if err := t.expr(b, e.Expr, textColumns); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case *ast.OperatorExpr:
+		column, err := t.column(e.Property)
+		if err != nil {
+			return err
+		}
+		op, err := operator(e.Operator)
+		if err != nil {
+			return err
+		}
+		if e.Value == nil {
+			return fmt.Errorf("%s: property %q: nil value", pkgName, e.Property)
+		}
+		b.WriteString(column)
+		b.WriteString(" ")
+		b.WriteString(op)
+		b.WriteString(" ")
+		b.WriteString(t.placeholder(e.Value.Raw()))
+	case *ast.ColonExpr:
+		column, err := t.column(e.Property)
+		if err != nil {
+			return err
+		}
+		return t.expr(b, e.Expr, []string{column})
+	case *ast.KeywordExpr:
+		if textColumns == nil {
+			if len(t.KeywordColumns) == 0 {
+				return fmt.Errorf("%s: no keyword columns configured", pkgName)
+			}
+			textColumns = t.KeywordColumns
+		}
+		return t.textSearch(b, e.Value, textColumns)
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func (t *translation) join(b *strings.Builder, exprs []ast.Expr, sep string, textColumns []string) error {
+	if len(exprs) == 0 {
+		return fmt.Errorf("%s: empty%sexpression", pkgName, strings.ToLower(sep))
+	}
+	b.WriteString("(")
+	for i, v := range exprs {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		if err := t.expr(b, v, textColumns); err != nil {
+			return err
+		}
+	}
+	b.WriteString(")")
+	return nil
+}
+
+func (t *translation) textSearch(b *strings.Builder, v ast.Value, columns []string) error {
+	s, err := ast.AsString(v)
+	if err != nil {
+		return err
+	}
+	// quoted strings with several words are phrases
+	fn := "plainto_tsquery"
+	if strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+		fn = "phraseto_tsquery"
+	}
+	query := fn + "(" + t.config() + t.placeholder(s) + ")"
+	if len(columns) > 1 {
+		b.WriteString("(")
+	}
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		b.WriteString("to_tsvector(" + t.config() + column + ") @@ " + query)
+	}
+	if len(columns) > 1 {
+		b.WriteString(")")
+	}
+	return nil
+}
+
+func (t *translation) config() string {
+	if t.TextSearchConfig == "" {
+		return ""
+	}
+	return quoteLiteral(t.TextSearchConfig) + ", "
+}
+
+func (t *translation) column(property string) (string, error) {
+	if column, ok := t.Columns[property]; ok {
+		return column, nil
+	}
+	if property == "" {
+		return "", fmt.Errorf("%s: empty property", pkgName)
+	}
+	var names []string
+	for _, name := range strings.Split(property, ".") {
+		names = append(names, quoteIdent(name))
+	}
+	return strings.Join(names, "."), nil
+}
+
+func operator(op ast.Op) (string, error) {
+	switch op {
+	case ast.OpEq:
+		return "=", nil
+	case ast.OpNeq:
+		return "<>", nil
+	case ast.OpLt, ast.OpLe, ast.OpGt, ast.OpGe:
+		return op.String(), nil
+	default:
+		return "", fmt.Errorf("%s: invalid operator %v", pkgName, op)
+	}
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return `'` + strings.Replace(s, `'`, `''`, -1) + `'`
+}
@@ -0,0 +1,79 @@
+package pgquery
+
+import (
+	"testing"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	tr := &Translator{
+		Columns: map[string]string{
+			"title": "books.title",
+		},
+		KeywordColumns:   []string{"books.title", "books.body"},
+		TextSearchConfig: "english",
+	}
+	cases := []struct {
+		Query string
+		SQL   string
+		Args  []interface{}
+	}{
+		{
+			`users.name = kamichidu`,
+			`"users"."name" = $1`,
+			[]interface{}{"kamichidu"},
+		},
+		{
+			`NOT users.name = kamichidu OR users.type != dogs AND pages >= 500`,
+			`((NOT ("users"."name" = $1) OR "users"."type" <> $2) AND "pages" >= $3)`,
+			[]interface{}{"kamichidu", "dogs", int64(500)},
+		},
+		{
+			`title:"Harry Potter" AND published < 2000-01-01`,
+			`(to_tsvector('english', books.title) @@ phraseto_tsquery('english', $1) AND "published" < $2)`,
+			[]interface{}{"Harry Potter", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			`color:(red OR NOT white)`,
+			`(to_tsvector('english', "color") @@ plainto_tsquery('english', $1) OR NOT (to_tsvector('english', "color") @@ plainto_tsquery('english', $2)))`,
+			[]interface{}{"red", "white"},
+		},
+		{
+			`guitar`,
+			`(to_tsvector('english', books.title) @@ plainto_tsquery('english', $1) OR to_tsvector('english', books.body) @@ plainto_tsquery('english', $1))`,
+			[]interface{}{"guitar"},
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		sql, args, err := tr.Translate(expr)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		assert.Equal(t, c.SQL, sql, c.Query)
+		assert.Equal(t, c.Args, args, c.Query)
+	}
+
+	t.Run("placeholder offset", func(t *testing.T) {
+		tr := &Translator{PlaceholderOffset: 2}
+		sql, _, err := tr.Translate(ast.And{
+			&ast.OperatorExpr{Property: "a", Operator: ast.OpEq, Value: ast.IntegerValue(1)},
+			&ast.OperatorExpr{Property: `b"c`, Operator: ast.OpEq, Value: ast.IntegerValue(2)},
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, `("a" = $3 AND "b""c" = $4)`, sql)
+		}
+	})
+
+	t.Run("no keyword columns", func(t *testing.T) {
+		_, _, err := (&Translator{}).Translate(&ast.KeywordExpr{Value: ast.StringValue("x")})
+		assert.Error(t, err)
+	})
+}
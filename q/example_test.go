@@ -0,0 +1,24 @@
+package q_test
+
+import (
+	"fmt"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/q"
+)
+
+func Example() {
+	expr := q.And(
+		q.Eq("users.type", "dogs"),
+		q.Not(q.Field("country", q.Keyword("france"))),
+		q.Or(q.Keyword(`say "hello"`), q.Keyword("NOTE")),
+		q.Ge("users.born", q.Date(1965, 1, 1)),
+	)
+	s, err := searchquery.Format(expr)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s)
+	// Output:
+	// users.type = dogs AND NOT country:france AND ("say \"hello\"" OR "NOTE") AND users.born >= 1965-01-01
+}
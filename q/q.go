@@ -0,0 +1,180 @@
+// Package q provides helpers to build query expressions in code.
+//
+// Expressions built with this package can be printed as query strings by searchquery.Format,
+// which takes care of quoting values.
+package q
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/q"
+)
+
+func And(exprs ...ast.Expr) ast.Expr {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return ast.And(exprs)
+}
+
+func Or(exprs ...ast.Expr) ast.Expr {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return ast.Or(exprs)
+}
+
+func Not(expr ast.Expr) ast.Expr {
+	return &ast.Not{
+		Expr: expr,
+	}
+}
+
+// Field builds `property:expr`.
+func Field(property string, expr ast.Expr) ast.Expr {
+	return &ast.ColonExpr{
+		Property: property,
+		Expr:     expr,
+	}
+}
+
+// Keyword builds a keyword expression from v, see Value for accepted types.
+func Keyword(v interface{}) ast.Expr {
+	return &ast.KeywordExpr{
+		Value: Value(v),
+	}
+}
+
+// Op builds `property op value`, see Value for accepted types.
+func Op(property string, op ast.Op, v interface{}) ast.Expr {
+	return &ast.OperatorExpr{
+		Property: property,
+		Operator: op,
+		Value:    Value(v),
+	}
+}
+
+func Eq(property string, v interface{}) ast.Expr {
+	return Op(property, ast.OpEq, v)
+}
+
+func Neq(property string, v interface{}) ast.Expr {
+	return Op(property, ast.OpNeq, v)
+}
+
+func Lt(property string, v interface{}) ast.Expr {
+	return Op(property, ast.OpLt, v)
+}
+
+func Le(property string, v interface{}) ast.Expr {
+	return Op(property, ast.OpLe, v)
+}
+
+func Gt(property string, v interface{}) ast.Expr {
+	return Op(property, ast.OpGt, v)
+}
+
+func Ge(property string, v interface{}) ast.Expr {
+	return Op(property, ast.OpGe, v)
+}
+
+func String(s string) ast.Value {
+	return ast.StringValue(s)
+}
+
+func Int(i int64) ast.Value {
+	return ast.IntegerValue(i)
+}
+
+// Float builds a float value, f must not be NaN, which no query can compare with, otherwise Float panics.
+func Float(f float64) ast.Value {
+	value, err := floatValue(f)
+	if err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+func Bool(b bool) ast.Value {
+	return ast.BoolValue(b)
+}
+
+func Time(t time.Time) ast.Value {
+	return ast.TimeValue(t)
+}
+
+// Date builds a time value which points the midnight of the given date in UTC.
+func Date(year int, month time.Month, day int) ast.Value {
+	return ast.TimeValue(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// Value converts v into ast.Value, see ValueOf for accepted types. Value panics if ValueOf returns an error.
+func Value(v interface{}) ast.Value {
+	value, err := ValueOf(v)
+	if err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+// ValueOf converts v into ast.Value.
+// v must be an ast.Value, string, bool, time.Time, any integer type within the range of int64, or any float
+// type but NaN.
+func ValueOf(v interface{}) (ast.Value, error) {
+	switch v := v.(type) {
+	case ast.Value:
+		return v, nil
+	case string:
+		return ast.StringValue(v), nil
+	case bool:
+		return ast.BoolValue(v), nil
+	case time.Time:
+		return ast.TimeValue(v), nil
+	case int:
+		return ast.IntegerValue(v), nil
+	case int8:
+		return ast.IntegerValue(v), nil
+	case int16:
+		return ast.IntegerValue(v), nil
+	case int32:
+		return ast.IntegerValue(v), nil
+	case int64:
+		return ast.IntegerValue(v), nil
+	case uint:
+		return uintValue(uint64(v))
+	case uint8:
+		return ast.IntegerValue(v), nil
+	case uint16:
+		return ast.IntegerValue(v), nil
+	case uint32:
+		return ast.IntegerValue(v), nil
+	case uint64:
+		return uintValue(v)
+	case float32:
+		return floatValue(float64(v))
+	case float64:
+		return floatValue(v)
+	default:
+		return nil, fmt.Errorf("%s: unsupported value type %T", pkgName, v)
+	}
+}
+
+func uintValue(u uint64) (ast.Value, error) {
+	if u > math.MaxInt64 {
+		return nil, fmt.Errorf("%s: %d overflows an integer value", pkgName, u)
+	}
+	return ast.IntegerValue(u), nil
+}
+
+func floatValue(f float64) (ast.Value, error) {
+	if math.IsNaN(f) {
+		return nil, fmt.Errorf("%s: NaN is not a value", pkgName)
+	}
+	return ast.FloatValue(f), nil
+}
@@ -0,0 +1,50 @@
+package q
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue(t *testing.T) {
+	assert.Equal(t, ast.FloatValue(1.5), Value(float32(1.5)))
+	assert.Equal(t, ast.IntegerValue(3), Value(uint8(3)))
+	assert.Panics(t, func() { Float(math.NaN()) })
+	assert.Panics(t, func() { Value(math.NaN()) })
+	assert.Panics(t, func() { Value(struct{}{}) })
+	assert.Panics(t, func() { Value(uint64(math.MaxUint64)) })
+}
+
+func TestValueOf(t *testing.T) {
+	cases := []struct {
+		Input    interface{}
+		Expected ast.Value
+	}{
+		{"a", ast.StringValue("a")},
+		{uint(math.MaxInt64), ast.IntegerValue(math.MaxInt64)},
+		{uint64(math.MaxInt64), ast.IntegerValue(math.MaxInt64)},
+		{uint32(math.MaxUint32), ast.IntegerValue(math.MaxUint32)},
+		{float32(1.5), ast.FloatValue(1.5)},
+	}
+	for _, c := range cases {
+		v, err := ValueOf(c.Input)
+		if assert.NoError(t, err, "%#v", c.Input) {
+			assert.Equal(t, c.Expected, v, "%#v", c.Input)
+		}
+	}
+
+	for _, v := range []interface{}{
+		uint(math.MaxInt64) + 1,
+		uint64(math.MaxUint64),
+		uintptr(1),
+		math.NaN(),
+		float32(math.NaN()),
+		struct{}{},
+		nil,
+	} {
+		_, err := ValueOf(v)
+		assert.Error(t, err, "%#v", v)
+	}
+}
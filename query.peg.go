@@ -17,10 +17,23 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	ruleQuery
+	ruleSegment
+	ruleJunk
 	ruleExprs
 	ruleExpr
+	ruleExpectExpr
+	ruleExpectOperator
+	ruleExpectValue
+	ruleExpectString
+	ruleExpectClose
+	ruleExpectQuote
+	ruleAnd
+	ruleOr
+	ruleNot
+	ruleColon
 	ruleProperty
 	ruleOperator
+	rulePartialOperator
 	ruleValue
 	ruleTime
 	ruleString
@@ -43,10 +56,10 @@ const (
 	ruleAction7
 	ruleAction8
 	ruleAction9
-	rulePegText
 	ruleAction10
 	ruleAction11
 	ruleAction12
+	rulePegText
 	ruleAction13
 	ruleAction14
 	ruleAction15
@@ -60,6 +73,9 @@ const (
 	ruleAction23
 	ruleAction24
 	ruleAction25
+	ruleAction26
+	ruleAction27
+	ruleAction28
 
 	rulePre
 	ruleIn
@@ -69,10 +85,23 @@ const (
 var rul3s = [...]string{
 	"Unknown",
 	"Query",
+	"Segment",
+	"Junk",
 	"Exprs",
 	"Expr",
+	"ExpectExpr",
+	"ExpectOperator",
+	"ExpectValue",
+	"ExpectString",
+	"ExpectClose",
+	"ExpectQuote",
+	"And",
+	"Or",
+	"Not",
+	"Colon",
 	"Property",
 	"Operator",
+	"PartialOperator",
 	"Value",
 	"Time",
 	"String",
@@ -95,10 +124,10 @@ var rul3s = [...]string{
 	"Action7",
 	"Action8",
 	"Action9",
-	"PegText",
 	"Action10",
 	"Action11",
 	"Action12",
+	"PegText",
 	"Action13",
 	"Action14",
 	"Action15",
@@ -112,6 +141,9 @@ var rul3s = [...]string{
 	"Action23",
 	"Action24",
 	"Action25",
+	"Action26",
+	"Action27",
+	"Action28",
 
 	"Pre_",
 	"_In_",
@@ -407,7 +439,7 @@ type Query struct {
 
 	Buffer string
 	buffer []rune
-	rules  [45]func() bool
+	rules  [61]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	Pretty bool
@@ -492,56 +524,62 @@ func (p *Query) Execute() {
 			text = string(_buffer[begin:end])
 
 		case ruleAction0:
-			p.reduceAnd()
+			p.reduceAnd(token.begin)
 		case ruleAction1:
 			p.finalize()
 		case ruleAction2:
-			p.pushOr()
+			p.pushOr(token.begin)
 		case ruleAction3:
-			p.pushOperatorExpr()
+			p.pushOperatorExpr(token.begin)
 		case ruleAction4:
-			p.pushColonExpr()
+			p.enter(token.begin)
 		case ruleAction5:
-			p.pushNewState()
+			p.pushColonExpr(token.begin)
 		case ruleAction6:
-			p.reduceAnd()
+			p.pushNewState(token.begin)
 		case ruleAction7:
-			p.popNewState()
+			p.reduceAnd(token.begin)
 		case ruleAction8:
-			p.pushNot()
+			p.popNewState()
 		case ruleAction9:
-			p.pushKeywordExpr()
+			p.enter(token.begin)
 		case ruleAction10:
-			p.pushProperty(buffer[begin:end])
+			p.pushNot(token.begin)
 		case ruleAction11:
-			p.pushOperator(ast.OpEq)
+			p.pushStemKeywordExpr(token.begin)
 		case ruleAction12:
-			p.pushOperator(ast.OpNeq)
+			p.pushKeywordExpr(token.begin)
 		case ruleAction13:
-			p.pushOperator(ast.OpNeq)
+			p.pushProperty(text)
 		case ruleAction14:
-			p.pushOperator(ast.OpLt)
+			p.pushOperator(ast.OpEq)
 		case ruleAction15:
-			p.pushOperator(ast.OpLe)
+			p.pushOperator(ast.OpNeq)
 		case ruleAction16:
-			p.pushOperator(ast.OpGt)
+			p.pushOperator(ast.OpNeq)
 		case ruleAction17:
-			p.pushOperator(ast.OpGe)
+			p.pushOperator(ast.OpLe)
 		case ruleAction18:
-			p.pushTimeValue(time.RFC3339, buffer[begin:end])
+			p.pushOperator(ast.OpLt)
 		case ruleAction19:
-			p.pushTimeValue("2006-01-02", buffer[begin:end])
+			p.pushOperator(ast.OpGe)
 		case ruleAction20:
-			p.pushStringValue(buffer[begin:end])
+			p.pushOperator(ast.OpGt)
 		case ruleAction21:
-			p.pushStringValue(buffer[begin:end])
+			p.pushTimeValue(begin, time.RFC3339, text)
 		case ruleAction22:
-			p.pushIntegerValue(buffer[begin:end])
+			p.pushTimeValue(begin, "2006-01-02", text)
 		case ruleAction23:
-			p.pushFloatValue(buffer[begin:end])
+			p.pushStringValue(text)
 		case ruleAction24:
-			p.pushBoolValue(true)
+			p.pushQuotedStringValue(text)
 		case ruleAction25:
+			p.pushIntegerValue(begin, text)
+		case ruleAction26:
+			p.pushFloatValue(begin, text)
+		case ruleAction27:
+			p.pushBoolValue(true)
+		case ruleAction28:
 			p.pushBoolValue(false)
 
 		}
@@ -612,36 +650,74 @@ func (p *Query) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 Query <- <(Spacing Exprs Action0 Spacing !. Action1)> */
+		/* 0 Query <- <((&{ !p.partial } Spacing Exprs Action0 Spacing !. Action1) / (&{ p.partial } Segment (Junk Segment)* !.))> */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
 				position1 := position
 				depth++
-				if !_rules[ruleSpacing]() {
-					goto l0
-				}
-				if !_rules[ruleExprs]() {
-					goto l0
-				}
-				if !_rules[ruleAction0]() {
-					goto l0
-				}
-				if !_rules[ruleSpacing]() {
-					goto l0
-				}
 				{
 					position2, tokenIndex2, depth2 := position, tokenIndex, depth
-					if !matchDot() {
-						goto l2
+					if !(!p.partial) {
+						goto l3
+					}
+					if !_rules[ruleSpacing]() {
+						goto l3
+					}
+					if !_rules[ruleExprs]() {
+						goto l3
+					}
+					if !_rules[ruleAction0]() {
+						goto l3
+					}
+					if !_rules[ruleSpacing]() {
+						goto l3
+					}
+					{
+						position4, tokenIndex4, depth4 := position, tokenIndex, depth
+						if !matchDot() {
+							goto l4
+						}
+						goto l3
+					l4:
+						position, tokenIndex, depth = position4, tokenIndex4, depth4
 					}
-					goto l0
-				l2:
+					if !_rules[ruleAction1]() {
+						goto l3
+					}
+					goto l2
+				l3:
 					position, tokenIndex, depth = position2, tokenIndex2, depth2
+					if !(p.partial) {
+						goto l0
+					}
+					if !_rules[ruleSegment]() {
+						goto l0
+					}
+				l5:
+					{
+						position6, tokenIndex6, depth6 := position, tokenIndex, depth
+						if !_rules[ruleJunk]() {
+							goto l6
+						}
+						if !_rules[ruleSegment]() {
+							goto l6
+						}
+						goto l5
+					l6:
+						position, tokenIndex, depth = position6, tokenIndex6, depth6
+					}
+					{
+						position7, tokenIndex7, depth7 := position, tokenIndex, depth
+						if !matchDot() {
+							goto l7
+						}
+						goto l0
+					l7:
+						position, tokenIndex, depth = position7, tokenIndex7, depth7
+					}
 				}
-				if !_rules[ruleAction1]() {
-					goto l0
-				}
+			l2:
 				depth--
 				add(ruleQuery, position1)
 			}
@@ -650,1274 +726,1818 @@ func (p *Query) Init() {
 			position, tokenIndex, depth = position0, tokenIndex0, depth0
 			return false
 		},
-		/* 1 Exprs <- <(Expr ((Spacing ('A' 'N' 'D') Spacing Expr) / (Spacing ('O' 'R') Spacing Expr Action2) / (Spacing Expr))*)> */
+		/* 1 Segment <- <(Spacing (Exprs / ExpectExpr)? Spacing)> */
 		func() bool {
-			position3, tokenIndex3, depth3 := position, tokenIndex, depth
+			position8, tokenIndex8, depth8 := position, tokenIndex, depth
 			{
-				position4 := position
+				position9 := position
 				depth++
-				if !_rules[ruleExpr]() {
-					goto l3
+				if !_rules[ruleSpacing]() {
+					goto l8
 				}
-			l5:
 				{
-					position6, tokenIndex6, depth6 := position, tokenIndex, depth
+					position10, tokenIndex10, depth10 := position, tokenIndex, depth
 					{
-						position7, tokenIndex7, depth7 := position, tokenIndex, depth
-						if !_rules[ruleSpacing]() {
-							goto l8
-						}
-						if buffer[position] != rune('A') {
-							goto l8
+						position12, tokenIndex12, depth12 := position, tokenIndex, depth
+						if !_rules[ruleExprs]() {
+							goto l13
 						}
-						position++
-						if buffer[position] != rune('N') {
-							goto l8
+						goto l12
+					l13:
+						position, tokenIndex, depth = position12, tokenIndex12, depth12
+						if !_rules[ruleExpectExpr]() {
+							goto l10
 						}
-						position++
-						if buffer[position] != rune('D') {
-							goto l8
+					}
+				l12:
+					goto l11
+				l10:
+					position, tokenIndex, depth = position10, tokenIndex10, depth10
+				}
+			l11:
+				if !_rules[ruleSpacing]() {
+					goto l8
+				}
+				depth--
+				add(ruleSegment, position9)
+			}
+			return true
+		l8:
+			position, tokenIndex, depth = position8, tokenIndex8, depth8
+			return false
+		},
+		/* 2 Junk <- <([\u0080-\U0010ffff]+ / .)> */
+		func() bool {
+			position14, tokenIndex14, depth14 := position, tokenIndex, depth
+			{
+				position15 := position
+				depth++
+				{
+					position16, tokenIndex16, depth16 := position, tokenIndex, depth
+					if c := buffer[position]; c < rune('\u0080') || c > rune('\U0010ffff') {
+						goto l17
+					}
+					position++
+				l18:
+					{
+						position19, tokenIndex19, depth19 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('\u0080') || c > rune('\U0010ffff') {
+							goto l19
 						}
 						position++
+						goto l18
+					l19:
+						position, tokenIndex, depth = position19, tokenIndex19, depth19
+					}
+					goto l16
+				l17:
+					position, tokenIndex, depth = position16, tokenIndex16, depth16
+					if !matchDot() {
+						goto l14
+					}
+				}
+			l16:
+				depth--
+				add(ruleJunk, position15)
+			}
+			return true
+		l14:
+			position, tokenIndex, depth = position14, tokenIndex14, depth14
+			return false
+		},
+		/* 3 Exprs <- <(Expr ((Spacing And Spacing (Expr / ExpectExpr)) / (Spacing Or Spacing ((Expr Action2) / ExpectExpr)) / (Spacing Expr))*)> */
+		func() bool {
+			position20, tokenIndex20, depth20 := position, tokenIndex, depth
+			{
+				position21 := position
+				depth++
+				if !_rules[ruleExpr]() {
+					goto l20
+				}
+			l22:
+				{
+					position23, tokenIndex23, depth23 := position, tokenIndex, depth
+					{
+						position24, tokenIndex24, depth24 := position, tokenIndex, depth
 						if !_rules[ruleSpacing]() {
-							goto l8
+							goto l25
 						}
-						if !_rules[ruleExpr]() {
-							goto l8
+						if !_rules[ruleAnd]() {
+							goto l25
 						}
-						goto l7
-					l8:
-						position, tokenIndex, depth = position7, tokenIndex7, depth7
 						if !_rules[ruleSpacing]() {
-							goto l9
-						}
-						if buffer[position] != rune('O') {
-							goto l9
+							goto l25
 						}
-						position++
-						if buffer[position] != rune('R') {
-							goto l9
+						{
+							position26, tokenIndex26, depth26 := position, tokenIndex, depth
+							if !_rules[ruleExpr]() {
+								goto l27
+							}
+							goto l26
+						l27:
+							position, tokenIndex, depth = position26, tokenIndex26, depth26
+							if !_rules[ruleExpectExpr]() {
+								goto l25
+							}
 						}
-						position++
+					l26:
+						goto l24
+					l25:
+						position, tokenIndex, depth = position24, tokenIndex24, depth24
 						if !_rules[ruleSpacing]() {
-							goto l9
+							goto l28
 						}
-						if !_rules[ruleExpr]() {
-							goto l9
+						if !_rules[ruleOr]() {
+							goto l28
 						}
-						if !_rules[ruleAction2]() {
-							goto l9
+						if !_rules[ruleSpacing]() {
+							goto l28
 						}
-						goto l7
-					l9:
-						position, tokenIndex, depth = position7, tokenIndex7, depth7
+						{
+							position29, tokenIndex29, depth29 := position, tokenIndex, depth
+							if !_rules[ruleExpr]() {
+								goto l30
+							}
+							if !_rules[ruleAction2]() {
+								goto l30
+							}
+							goto l29
+						l30:
+							position, tokenIndex, depth = position29, tokenIndex29, depth29
+							if !_rules[ruleExpectExpr]() {
+								goto l28
+							}
+						}
+					l29:
+						goto l24
+					l28:
+						position, tokenIndex, depth = position24, tokenIndex24, depth24
 						if !_rules[ruleSpacing]() {
-							goto l6
+							goto l23
 						}
 						if !_rules[ruleExpr]() {
-							goto l6
+							goto l23
 						}
 					}
-				l7:
-					goto l5
-				l6:
-					position, tokenIndex, depth = position6, tokenIndex6, depth6
+				l24:
+					goto l22
+				l23:
+					position, tokenIndex, depth = position23, tokenIndex23, depth23
 				}
 				depth--
-				add(ruleExprs, position4)
+				add(ruleExprs, position21)
 			}
 			return true
-		l3:
-			position, tokenIndex, depth = position3, tokenIndex3, depth3
+		l20:
+			position, tokenIndex, depth = position20, tokenIndex20, depth20
 			return false
 		},
-		/* 2 Expr <- <((Property Spacing ((Operator Spacing Value Action3) / (':' Spacing Expr Action4))) / ('(' Action5 Spacing Exprs Action6 Spacing ')' Action7) / ('N' 'O' 'T' Spacing Expr Action8) / (Value Action9))> */
+		/* 4 Expr <- <((Property Spacing ((Operator Spacing ((Value Action3) / ExpectValue)) / PartialOperator / (Action4 Colon Spacing ((Expr Action5) / ExpectExpr)) / ExpectOperator)) / (Action6 '(' Spacing ((Exprs Action7 Spacing ((')' Action8) / ExpectClose)) / ExpectExpr)) / (Action9 Not Spacing ((Expr Action10) / ExpectExpr)) / ('~' ((String Action11) / ExpectString)) / (Value Action12))> */
 		func() bool {
-			position10, tokenIndex10, depth10 := position, tokenIndex, depth
+			position31, tokenIndex31, depth31 := position, tokenIndex, depth
 			{
-				position11 := position
+				position32 := position
 				depth++
 				{
-					position12, tokenIndex12, depth12 := position, tokenIndex, depth
+					position33, tokenIndex33, depth33 := position, tokenIndex, depth
 					if !_rules[ruleProperty]() {
-						goto l13
+						goto l34
 					}
 					if !_rules[ruleSpacing]() {
-						goto l13
+						goto l34
 					}
 					{
-						position14, tokenIndex14, depth14 := position, tokenIndex, depth
+						position35, tokenIndex35, depth35 := position, tokenIndex, depth
 						if !_rules[ruleOperator]() {
-							goto l15
+							goto l36
 						}
 						if !_rules[ruleSpacing]() {
-							goto l15
+							goto l36
 						}
-						if !_rules[ruleValue]() {
-							goto l15
+						{
+							position37, tokenIndex37, depth37 := position, tokenIndex, depth
+							if !_rules[ruleValue]() {
+								goto l38
+							}
+							if !_rules[ruleAction3]() {
+								goto l38
+							}
+							goto l37
+						l38:
+							position, tokenIndex, depth = position37, tokenIndex37, depth37
+							if !_rules[ruleExpectValue]() {
+								goto l36
+							}
 						}
-						if !_rules[ruleAction3]() {
-							goto l15
+					l37:
+						goto l35
+					l36:
+						position, tokenIndex, depth = position35, tokenIndex35, depth35
+						if !_rules[rulePartialOperator]() {
+							goto l39
 						}
-						goto l14
-					l15:
-						position, tokenIndex, depth = position14, tokenIndex14, depth14
-						if buffer[position] != rune(':') {
-							goto l13
+						goto l35
+					l39:
+						position, tokenIndex, depth = position35, tokenIndex35, depth35
+						if !_rules[ruleAction4]() {
+							goto l40
+						}
+						if !_rules[ruleColon]() {
+							goto l40
 						}
-						position++
 						if !_rules[ruleSpacing]() {
-							goto l13
+							goto l40
 						}
-						if !_rules[ruleExpr]() {
-							goto l13
+						{
+							position41, tokenIndex41, depth41 := position, tokenIndex, depth
+							if !_rules[ruleExpr]() {
+								goto l42
+							}
+							if !_rules[ruleAction5]() {
+								goto l42
+							}
+							goto l41
+						l42:
+							position, tokenIndex, depth = position41, tokenIndex41, depth41
+							if !_rules[ruleExpectExpr]() {
+								goto l40
+							}
 						}
-						if !_rules[ruleAction4]() {
-							goto l13
+					l41:
+						goto l35
+					l40:
+						position, tokenIndex, depth = position35, tokenIndex35, depth35
+						if !_rules[ruleExpectOperator]() {
+							goto l34
 						}
 					}
-				l14:
-					goto l12
-				l13:
-					position, tokenIndex, depth = position12, tokenIndex12, depth12
+				l35:
+					goto l33
+				l34:
+					position, tokenIndex, depth = position33, tokenIndex33, depth33
+					if !_rules[ruleAction6]() {
+						goto l43
+					}
 					if buffer[position] != rune('(') {
-						goto l16
+						goto l43
 					}
 					position++
-					if !_rules[ruleAction5]() {
-						goto l16
-					}
 					if !_rules[ruleSpacing]() {
-						goto l16
-					}
-					if !_rules[ruleExprs]() {
-						goto l16
+						goto l43
 					}
-					if !_rules[ruleAction6]() {
-						goto l16
+					{
+						position44, tokenIndex44, depth44 := position, tokenIndex, depth
+						if !_rules[ruleExprs]() {
+							goto l45
+						}
+						if !_rules[ruleAction7]() {
+							goto l45
+						}
+						if !_rules[ruleSpacing]() {
+							goto l45
+						}
+						{
+							position46, tokenIndex46, depth46 := position, tokenIndex, depth
+							if buffer[position] != rune(')') {
+								goto l47
+							}
+							position++
+							if !_rules[ruleAction8]() {
+								goto l47
+							}
+							goto l46
+						l47:
+							position, tokenIndex, depth = position46, tokenIndex46, depth46
+							if !_rules[ruleExpectClose]() {
+								goto l45
+							}
+						}
+					l46:
+						goto l44
+					l45:
+						position, tokenIndex, depth = position44, tokenIndex44, depth44
+						if !_rules[ruleExpectExpr]() {
+							goto l43
+						}
 					}
-					if !_rules[ruleSpacing]() {
-						goto l16
+				l44:
+					goto l33
+				l43:
+					position, tokenIndex, depth = position33, tokenIndex33, depth33
+					if !_rules[ruleAction9]() {
+						goto l48
 					}
-					if buffer[position] != rune(')') {
-						goto l16
+					if !_rules[ruleNot]() {
+						goto l48
 					}
-					position++
-					if !_rules[ruleAction7]() {
-						goto l16
+					if !_rules[ruleSpacing]() {
+						goto l48
 					}
-					goto l12
-				l16:
-					position, tokenIndex, depth = position12, tokenIndex12, depth12
-					if buffer[position] != rune('N') {
-						goto l17
+					{
+						position49, tokenIndex49, depth49 := position, tokenIndex, depth
+						if !_rules[ruleExpr]() {
+							goto l50
+						}
+						if !_rules[ruleAction10]() {
+							goto l50
+						}
+						goto l49
+					l50:
+						position, tokenIndex, depth = position49, tokenIndex49, depth49
+						if !_rules[ruleExpectExpr]() {
+							goto l48
+						}
 					}
-					position++
-					if buffer[position] != rune('O') {
-						goto l17
+				l49:
+					goto l33
+				l48:
+					position, tokenIndex, depth = position33, tokenIndex33, depth33
+					if buffer[position] != rune('~') {
+						goto l51
 					}
 					position++
-					if buffer[position] != rune('T') {
-						goto l17
+					{
+						position52, tokenIndex52, depth52 := position, tokenIndex, depth
+						if !_rules[ruleString]() {
+							goto l53
+						}
+						if !_rules[ruleAction11]() {
+							goto l53
+						}
+						goto l52
+					l53:
+						position, tokenIndex, depth = position52, tokenIndex52, depth52
+						if !_rules[ruleExpectString]() {
+							goto l51
+						}
 					}
-					position++
-					if !_rules[ruleSpacing]() {
-						goto l17
+				l52:
+					goto l33
+				l51:
+					position, tokenIndex, depth = position33, tokenIndex33, depth33
+					if !_rules[ruleValue]() {
+						goto l31
 					}
-					if !_rules[ruleExpr]() {
-						goto l17
+					if !_rules[ruleAction12]() {
+						goto l31
 					}
-					if !_rules[ruleAction8]() {
-						goto l17
+				}
+			l33:
+				depth--
+				add(ruleExpr, position32)
+			}
+			return true
+		l31:
+			position, tokenIndex, depth = position31, tokenIndex31, depth31
+			return false
+		},
+		/* 5 ExpectExpr <- <(&{ p.partial } !.)> */
+		func() bool {
+			position54, tokenIndex54, depth54 := position, tokenIndex, depth
+			{
+				position55 := position
+				depth++
+				if !(p.partial) {
+					goto l54
+				}
+				{
+					position56, tokenIndex56, depth56 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l56
 					}
-					goto l12
-				l17:
-					position, tokenIndex, depth = position12, tokenIndex12, depth12
-					if !_rules[ruleValue]() {
-						goto l10
+					goto l54
+				l56:
+					position, tokenIndex, depth = position56, tokenIndex56, depth56
+				}
+				depth--
+				add(ruleExpectExpr, position55)
+			}
+			return true
+		l54:
+			position, tokenIndex, depth = position54, tokenIndex54, depth54
+			return false
+		},
+		/* 6 ExpectOperator <- <(&{ p.partial } !.)> */
+		func() bool {
+			position57, tokenIndex57, depth57 := position, tokenIndex, depth
+			{
+				position58 := position
+				depth++
+				if !(p.partial) {
+					goto l57
+				}
+				{
+					position59, tokenIndex59, depth59 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l59
 					}
-					if !_rules[ruleAction9]() {
-						goto l10
+					goto l57
+				l59:
+					position, tokenIndex, depth = position59, tokenIndex59, depth59
+				}
+				depth--
+				add(ruleExpectOperator, position58)
+			}
+			return true
+		l57:
+			position, tokenIndex, depth = position57, tokenIndex57, depth57
+			return false
+		},
+		/* 7 ExpectValue <- <(&{ p.partial } !.)> */
+		func() bool {
+			position60, tokenIndex60, depth60 := position, tokenIndex, depth
+			{
+				position61 := position
+				depth++
+				if !(p.partial) {
+					goto l60
+				}
+				{
+					position62, tokenIndex62, depth62 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l62
 					}
+					goto l60
+				l62:
+					position, tokenIndex, depth = position62, tokenIndex62, depth62
 				}
-			l12:
 				depth--
-				add(ruleExpr, position11)
+				add(ruleExpectValue, position61)
 			}
 			return true
-		l10:
-			position, tokenIndex, depth = position10, tokenIndex10, depth10
+		l60:
+			position, tokenIndex, depth = position60, tokenIndex60, depth60
 			return false
 		},
-		/* 3 Property <- <(<(([a-z] / [A-Z]) ('_' / [a-z] / [A-Z] / [0-9])* ('.' ([a-z] / [A-Z]) ('_' / [a-z] / [A-Z] / [0-9])*)*)> Action10)> */
+		/* 8 ExpectString <- <(&{ p.partial } !.)> */
 		func() bool {
-			position18, tokenIndex18, depth18 := position, tokenIndex, depth
+			position63, tokenIndex63, depth63 := position, tokenIndex, depth
 			{
-				position19 := position
+				position64 := position
 				depth++
+				if !(p.partial) {
+					goto l63
+				}
 				{
-					position20 := position
-					depth++
-					{
-						position21, tokenIndex21, depth21 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l22
-						}
-						position++
-						goto l21
-					l22:
-						position, tokenIndex, depth = position21, tokenIndex21, depth21
-						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l18
-						}
-						position++
+					position65, tokenIndex65, depth65 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l65
 					}
-				l21:
-				l23:
+					goto l63
+				l65:
+					position, tokenIndex, depth = position65, tokenIndex65, depth65
+				}
+				depth--
+				add(ruleExpectString, position64)
+			}
+			return true
+		l63:
+			position, tokenIndex, depth = position63, tokenIndex63, depth63
+			return false
+		},
+		/* 9 ExpectClose <- <(&{ p.partial } !.)> */
+		func() bool {
+			position66, tokenIndex66, depth66 := position, tokenIndex, depth
+			{
+				position67 := position
+				depth++
+				if !(p.partial) {
+					goto l66
+				}
+				{
+					position68, tokenIndex68, depth68 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l68
+					}
+					goto l66
+				l68:
+					position, tokenIndex, depth = position68, tokenIndex68, depth68
+				}
+				depth--
+				add(ruleExpectClose, position67)
+			}
+			return true
+		l66:
+			position, tokenIndex, depth = position66, tokenIndex66, depth66
+			return false
+		},
+		/* 10 ExpectQuote <- <(&{ p.partial } !.)> */
+		func() bool {
+			position69, tokenIndex69, depth69 := position, tokenIndex, depth
+			{
+				position70 := position
+				depth++
+				if !(p.partial) {
+					goto l69
+				}
+				{
+					position71, tokenIndex71, depth71 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l71
+					}
+					goto l69
+				l71:
+					position, tokenIndex, depth = position71, tokenIndex71, depth71
+				}
+				depth--
+				add(ruleExpectQuote, position70)
+			}
+			return true
+		l69:
+			position, tokenIndex, depth = position69, tokenIndex69, depth69
+			return false
+		},
+		/* 11 And <- <('A' 'N' 'D')> */
+		func() bool {
+			position72, tokenIndex72, depth72 := position, tokenIndex, depth
+			{
+				position73 := position
+				depth++
+				if buffer[position] != rune('A') {
+					goto l72
+				}
+				position++
+				if buffer[position] != rune('N') {
+					goto l72
+				}
+				position++
+				if buffer[position] != rune('D') {
+					goto l72
+				}
+				position++
+				depth--
+				add(ruleAnd, position73)
+			}
+			return true
+		l72:
+			position, tokenIndex, depth = position72, tokenIndex72, depth72
+			return false
+		},
+		/* 12 Or <- <('O' 'R')> */
+		func() bool {
+			position74, tokenIndex74, depth74 := position, tokenIndex, depth
+			{
+				position75 := position
+				depth++
+				if buffer[position] != rune('O') {
+					goto l74
+				}
+				position++
+				if buffer[position] != rune('R') {
+					goto l74
+				}
+				position++
+				depth--
+				add(ruleOr, position75)
+			}
+			return true
+		l74:
+			position, tokenIndex, depth = position74, tokenIndex74, depth74
+			return false
+		},
+		/* 13 Not <- <('N' 'O' 'T')> */
+		func() bool {
+			position76, tokenIndex76, depth76 := position, tokenIndex, depth
+			{
+				position77 := position
+				depth++
+				if buffer[position] != rune('N') {
+					goto l76
+				}
+				position++
+				if buffer[position] != rune('O') {
+					goto l76
+				}
+				position++
+				if buffer[position] != rune('T') {
+					goto l76
+				}
+				position++
+				depth--
+				add(ruleNot, position77)
+			}
+			return true
+		l76:
+			position, tokenIndex, depth = position76, tokenIndex76, depth76
+			return false
+		},
+		/* 14 Colon <- <':'> */
+		func() bool {
+			position78, tokenIndex78, depth78 := position, tokenIndex, depth
+			{
+				position79 := position
+				depth++
+				if buffer[position] != rune(':') {
+					goto l78
+				}
+				position++
+				depth--
+				add(ruleColon, position79)
+			}
+			return true
+		l78:
+			position, tokenIndex, depth = position78, tokenIndex78, depth78
+			return false
+		},
+		/* 15 Property <- <(<(([a-z] / [A-Z]) ('_' / [a-z] / [A-Z] / [0-9])* ('.' ([a-z] / [A-Z]) ('_' / [a-z] / [A-Z] / [0-9])*)*)> Action13)> */
+		func() bool {
+			position80, tokenIndex80, depth80 := position, tokenIndex, depth
+			{
+				position81 := position
+				depth++
+				{
+					position82 := position
+					depth++
 					{
-						position24, tokenIndex24, depth24 := position, tokenIndex, depth
+						position83, tokenIndex83, depth83 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l84
+						}
+						position++
+						goto l83
+					l84:
+						position, tokenIndex, depth = position83, tokenIndex83, depth83
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l80
+						}
+						position++
+					}
+				l83:
+				l85:
+					{
+						position86, tokenIndex86, depth86 := position, tokenIndex, depth
 						{
-							position25, tokenIndex25, depth25 := position, tokenIndex, depth
+							position87, tokenIndex87, depth87 := position, tokenIndex, depth
 							if buffer[position] != rune('_') {
-								goto l26
+								goto l88
 							}
 							position++
-							goto l25
-						l26:
-							position, tokenIndex, depth = position25, tokenIndex25, depth25
+							goto l87
+						l88:
+							position, tokenIndex, depth = position87, tokenIndex87, depth87
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l27
+								goto l89
 							}
 							position++
-							goto l25
-						l27:
-							position, tokenIndex, depth = position25, tokenIndex25, depth25
+							goto l87
+						l89:
+							position, tokenIndex, depth = position87, tokenIndex87, depth87
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l28
+								goto l90
 							}
 							position++
-							goto l25
-						l28:
-							position, tokenIndex, depth = position25, tokenIndex25, depth25
+							goto l87
+						l90:
+							position, tokenIndex, depth = position87, tokenIndex87, depth87
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l24
+								goto l86
 							}
 							position++
 						}
-					l25:
-						goto l23
-					l24:
-						position, tokenIndex, depth = position24, tokenIndex24, depth24
+					l87:
+						goto l85
+					l86:
+						position, tokenIndex, depth = position86, tokenIndex86, depth86
 					}
-				l29:
+				l91:
 					{
-						position30, tokenIndex30, depth30 := position, tokenIndex, depth
+						position92, tokenIndex92, depth92 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l30
+							goto l92
 						}
 						position++
 						{
-							position31, tokenIndex31, depth31 := position, tokenIndex, depth
+							position93, tokenIndex93, depth93 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l32
+								goto l94
 							}
 							position++
-							goto l31
-						l32:
-							position, tokenIndex, depth = position31, tokenIndex31, depth31
+							goto l93
+						l94:
+							position, tokenIndex, depth = position93, tokenIndex93, depth93
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l30
+								goto l92
 							}
 							position++
 						}
-					l31:
-					l33:
+					l93:
+					l95:
 						{
-							position34, tokenIndex34, depth34 := position, tokenIndex, depth
+							position96, tokenIndex96, depth96 := position, tokenIndex, depth
 							{
-								position35, tokenIndex35, depth35 := position, tokenIndex, depth
+								position97, tokenIndex97, depth97 := position, tokenIndex, depth
 								if buffer[position] != rune('_') {
-									goto l36
+									goto l98
 								}
 								position++
-								goto l35
-							l36:
-								position, tokenIndex, depth = position35, tokenIndex35, depth35
+								goto l97
+							l98:
+								position, tokenIndex, depth = position97, tokenIndex97, depth97
 								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l37
+									goto l99
 								}
 								position++
-								goto l35
-							l37:
-								position, tokenIndex, depth = position35, tokenIndex35, depth35
+								goto l97
+							l99:
+								position, tokenIndex, depth = position97, tokenIndex97, depth97
 								if c := buffer[position]; c < rune('A') || c > rune('Z') {
-									goto l38
+									goto l100
 								}
 								position++
-								goto l35
-							l38:
-								position, tokenIndex, depth = position35, tokenIndex35, depth35
+								goto l97
+							l100:
+								position, tokenIndex, depth = position97, tokenIndex97, depth97
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l34
+									goto l96
 								}
 								position++
 							}
-						l35:
-							goto l33
-						l34:
-							position, tokenIndex, depth = position34, tokenIndex34, depth34
+						l97:
+							goto l95
+						l96:
+							position, tokenIndex, depth = position96, tokenIndex96, depth96
 						}
-						goto l29
-					l30:
-						position, tokenIndex, depth = position30, tokenIndex30, depth30
+						goto l91
+					l92:
+						position, tokenIndex, depth = position92, tokenIndex92, depth92
 					}
 					depth--
-					add(rulePegText, position20)
+					add(rulePegText, position82)
 				}
-				if !_rules[ruleAction10]() {
-					goto l18
+				if !_rules[ruleAction13]() {
+					goto l80
 				}
 				depth--
-				add(ruleProperty, position19)
+				add(ruleProperty, position81)
 			}
 			return true
-		l18:
-			position, tokenIndex, depth = position18, tokenIndex18, depth18
+		l80:
+			position, tokenIndex, depth = position80, tokenIndex80, depth80
 			return false
 		},
-		/* 4 Operator <- <(('=' Action11) / ('!' '=' Action12) / ('<' '>' Action13) / ('<' Action14) / ('<' '=' Action15) / ('>' Action16) / ('>' '=' Action17))> */
+		/* 16 Operator <- <(('=' Action14) / ('!' '=' Action15) / ('<' '>' Action16) / ('<' '=' Action17) / ('<' Action18) / ('>' '=' Action19) / ('>' Action20))> */
 		func() bool {
-			position39, tokenIndex39, depth39 := position, tokenIndex, depth
+			position101, tokenIndex101, depth101 := position, tokenIndex, depth
 			{
-				position40 := position
+				position102 := position
 				depth++
 				{
-					position41, tokenIndex41, depth41 := position, tokenIndex, depth
+					position103, tokenIndex103, depth103 := position, tokenIndex, depth
 					if buffer[position] != rune('=') {
-						goto l42
+						goto l104
 					}
 					position++
-					if !_rules[ruleAction11]() {
-						goto l42
+					if !_rules[ruleAction14]() {
+						goto l104
 					}
-					goto l41
-				l42:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
+					goto l103
+				l104:
+					position, tokenIndex, depth = position103, tokenIndex103, depth103
 					if buffer[position] != rune('!') {
-						goto l43
+						goto l105
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l43
+						goto l105
 					}
 					position++
-					if !_rules[ruleAction12]() {
-						goto l43
+					if !_rules[ruleAction15]() {
+						goto l105
 					}
-					goto l41
-				l43:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
+					goto l103
+				l105:
+					position, tokenIndex, depth = position103, tokenIndex103, depth103
 					if buffer[position] != rune('<') {
-						goto l44
+						goto l106
 					}
 					position++
 					if buffer[position] != rune('>') {
-						goto l44
-					}
-					position++
-					if !_rules[ruleAction13]() {
-						goto l44
-					}
-					goto l41
-				l44:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
-					if buffer[position] != rune('<') {
-						goto l45
+						goto l106
 					}
 					position++
-					if !_rules[ruleAction14]() {
-						goto l45
+					if !_rules[ruleAction16]() {
+						goto l106
 					}
-					goto l41
-				l45:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
+					goto l103
+				l106:
+					position, tokenIndex, depth = position103, tokenIndex103, depth103
 					if buffer[position] != rune('<') {
-						goto l46
+						goto l107
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l46
+						goto l107
 					}
 					position++
-					if !_rules[ruleAction15]() {
-						goto l46
+					if !_rules[ruleAction17]() {
+						goto l107
 					}
-					goto l41
-				l46:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
-					if buffer[position] != rune('>') {
-						goto l47
+					goto l103
+				l107:
+					position, tokenIndex, depth = position103, tokenIndex103, depth103
+					if buffer[position] != rune('<') {
+						goto l108
 					}
 					position++
-					if !_rules[ruleAction16]() {
-						goto l47
+					if !_rules[ruleAction18]() {
+						goto l108
 					}
-					goto l41
-				l47:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
+					goto l103
+				l108:
+					position, tokenIndex, depth = position103, tokenIndex103, depth103
 					if buffer[position] != rune('>') {
-						goto l39
+						goto l109
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l39
+						goto l109
 					}
 					position++
-					if !_rules[ruleAction17]() {
-						goto l39
+					if !_rules[ruleAction19]() {
+						goto l109
+					}
+					goto l103
+				l109:
+					position, tokenIndex, depth = position103, tokenIndex103, depth103
+					if buffer[position] != rune('>') {
+						goto l101
+					}
+					position++
+					if !_rules[ruleAction20]() {
+						goto l101
+					}
+				}
+			l103:
+				depth--
+				add(ruleOperator, position102)
+			}
+			return true
+		l101:
+			position, tokenIndex, depth = position101, tokenIndex101, depth101
+			return false
+		},
+		/* 17 PartialOperator <- <(&{ p.partial } '!' !.)> */
+		func() bool {
+			position110, tokenIndex110, depth110 := position, tokenIndex, depth
+			{
+				position111 := position
+				depth++
+				if !(p.partial) {
+					goto l110
+				}
+				if buffer[position] != rune('!') {
+					goto l110
+				}
+				position++
+				{
+					position112, tokenIndex112, depth112 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l112
 					}
+					goto l110
+				l112:
+					position, tokenIndex, depth = position112, tokenIndex112, depth112
 				}
-			l41:
 				depth--
-				add(ruleOperator, position40)
+				add(rulePartialOperator, position111)
 			}
 			return true
-		l39:
-			position, tokenIndex, depth = position39, tokenIndex39, depth39
+		l110:
+			position, tokenIndex, depth = position110, tokenIndex110, depth110
 			return false
 		},
-		/* 5 Value <- <(Time / Float / Integer / Bool / String)> */
+		/* 18 Value <- <(Time / Float / Integer / Bool / String)> */
 		func() bool {
-			position48, tokenIndex48, depth48 := position, tokenIndex, depth
+			position113, tokenIndex113, depth113 := position, tokenIndex, depth
 			{
-				position49 := position
+				position114 := position
 				depth++
 				{
-					position50, tokenIndex50, depth50 := position, tokenIndex, depth
+					position115, tokenIndex115, depth115 := position, tokenIndex, depth
 					if !_rules[ruleTime]() {
-						goto l51
+						goto l116
 					}
-					goto l50
-				l51:
-					position, tokenIndex, depth = position50, tokenIndex50, depth50
+					goto l115
+				l116:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
 					if !_rules[ruleFloat]() {
-						goto l52
+						goto l117
 					}
-					goto l50
-				l52:
-					position, tokenIndex, depth = position50, tokenIndex50, depth50
+					goto l115
+				l117:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
 					if !_rules[ruleInteger]() {
-						goto l53
+						goto l118
 					}
-					goto l50
-				l53:
-					position, tokenIndex, depth = position50, tokenIndex50, depth50
+					goto l115
+				l118:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
 					if !_rules[ruleBool]() {
-						goto l54
+						goto l119
 					}
-					goto l50
-				l54:
-					position, tokenIndex, depth = position50, tokenIndex50, depth50
+					goto l115
+				l119:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
 					if !_rules[ruleString]() {
-						goto l48
+						goto l113
 					}
 				}
-			l50:
+			l115:
 				depth--
-				add(ruleValue, position49)
+				add(ruleValue, position114)
 			}
 			return true
-		l48:
-			position, tokenIndex, depth = position48, tokenIndex48, depth48
+		l113:
+			position, tokenIndex, depth = position113, tokenIndex113, depth113
 			return false
 		},
-		/* 6 Time <- <((<([1-9] [0-9] [0-9] [0-9] '-' [0-9] [0-9] '-' [0-9] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] ':' [0-9] [0-9] 'Z')> Action18) / (<([1-9] [0-9] [0-9] [0-9] '-' [0-9] [0-9] '-' [0-9] [0-9])> Action19))> */
+		/* 19 Time <- <((<([1-9] [0-9] [0-9] [0-9] '-' [0-9] [0-9] '-' [0-9] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] ':' [0-9] [0-9] 'Z')> Action21) / (<([1-9] [0-9] [0-9] [0-9] '-' [0-9] [0-9] '-' [0-9] [0-9])> Action22))> */
 		func() bool {
-			position55, tokenIndex55, depth55 := position, tokenIndex, depth
+			position120, tokenIndex120, depth120 := position, tokenIndex, depth
 			{
-				position56 := position
+				position121 := position
 				depth++
 				{
-					position57, tokenIndex57, depth57 := position, tokenIndex, depth
+					position122, tokenIndex122, depth122 := position, tokenIndex, depth
 					{
-						position59 := position
+						position124 := position
 						depth++
 						if c := buffer[position]; c < rune('1') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if buffer[position] != rune('T') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if buffer[position] != rune(':') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if buffer[position] != rune(':') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l58
+							goto l123
 						}
 						position++
 						if buffer[position] != rune('Z') {
-							goto l58
+							goto l123
 						}
 						position++
 						depth--
-						add(rulePegText, position59)
+						add(rulePegText, position124)
 					}
-					if !_rules[ruleAction18]() {
-						goto l58
+					if !_rules[ruleAction21]() {
+						goto l123
 					}
-					goto l57
-				l58:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
+					goto l122
+				l123:
+					position, tokenIndex, depth = position122, tokenIndex122, depth122
 					{
-						position60 := position
+						position125 := position
 						depth++
 						if c := buffer[position]; c < rune('1') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l55
+							goto l120
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l55
+							goto l120
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l55
+							goto l120
 						}
 						position++
 						depth--
-						add(rulePegText, position60)
+						add(rulePegText, position125)
 					}
-					if !_rules[ruleAction19]() {
-						goto l55
+					if !_rules[ruleAction22]() {
+						goto l120
 					}
 				}
-			l57:
+			l122:
 				depth--
-				add(ruleTime, position56)
+				add(ruleTime, position121)
 			}
 			return true
-		l55:
-			position, tokenIndex, depth = position55, tokenIndex55, depth55
+		l120:
+			position, tokenIndex, depth = position120, tokenIndex120, depth120
 			return false
 		},
-		/* 7 String <- <(BareString / QuotedString)> */
+		/* 20 String <- <(BareString / QuotedString)> */
 		func() bool {
-			position61, tokenIndex61, depth61 := position, tokenIndex, depth
+			position126, tokenIndex126, depth126 := position, tokenIndex, depth
 			{
-				position62 := position
+				position127 := position
 				depth++
 				{
-					position63, tokenIndex63, depth63 := position, tokenIndex, depth
+					position128, tokenIndex128, depth128 := position, tokenIndex, depth
 					if !_rules[ruleBareString]() {
-						goto l64
+						goto l129
 					}
-					goto l63
-				l64:
-					position, tokenIndex, depth = position63, tokenIndex63, depth63
+					goto l128
+				l129:
+					position, tokenIndex, depth = position128, tokenIndex128, depth128
 					if !_rules[ruleQuotedString]() {
-						goto l61
+						goto l126
 					}
 				}
-			l63:
+			l128:
 				depth--
-				add(ruleString, position62)
+				add(ruleString, position127)
 			}
 			return true
-		l61:
-			position, tokenIndex, depth = position61, tokenIndex61, depth61
+		l126:
+			position, tokenIndex, depth = position126, tokenIndex126, depth126
 			return false
 		},
-		/* 8 BareString <- <(<(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9])*)> Action20)> */
+		/* 21 BareString <- <(<(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9])*)> Action23)> */
 		func() bool {
-			position65, tokenIndex65, depth65 := position, tokenIndex, depth
+			position130, tokenIndex130, depth130 := position, tokenIndex, depth
 			{
-				position66 := position
+				position131 := position
 				depth++
 				{
-					position67 := position
+					position132 := position
 					depth++
 					{
-						position68, tokenIndex68, depth68 := position, tokenIndex, depth
+						position133, tokenIndex133, depth133 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l69
+							goto l134
 						}
 						position++
-						goto l68
-					l69:
-						position, tokenIndex, depth = position68, tokenIndex68, depth68
+						goto l133
+					l134:
+						position, tokenIndex, depth = position133, tokenIndex133, depth133
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l65
+							goto l130
 						}
 						position++
 					}
-				l68:
-				l70:
+				l133:
+				l135:
 					{
-						position71, tokenIndex71, depth71 := position, tokenIndex, depth
+						position136, tokenIndex136, depth136 := position, tokenIndex, depth
 						{
-							position72, tokenIndex72, depth72 := position, tokenIndex, depth
+							position137, tokenIndex137, depth137 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l73
+								goto l138
 							}
 							position++
-							goto l72
-						l73:
-							position, tokenIndex, depth = position72, tokenIndex72, depth72
+							goto l137
+						l138:
+							position, tokenIndex, depth = position137, tokenIndex137, depth137
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l74
+								goto l139
 							}
 							position++
-							goto l72
-						l74:
-							position, tokenIndex, depth = position72, tokenIndex72, depth72
+							goto l137
+						l139:
+							position, tokenIndex, depth = position137, tokenIndex137, depth137
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l71
+								goto l136
 							}
 							position++
 						}
-					l72:
-						goto l70
-					l71:
-						position, tokenIndex, depth = position71, tokenIndex71, depth71
+					l137:
+						goto l135
+					l136:
+						position, tokenIndex, depth = position136, tokenIndex136, depth136
 					}
 					depth--
-					add(rulePegText, position67)
+					add(rulePegText, position132)
 				}
-				if !_rules[ruleAction20]() {
-					goto l65
+				if !_rules[ruleAction23]() {
+					goto l130
 				}
 				depth--
-				add(ruleBareString, position66)
+				add(ruleBareString, position131)
 			}
 			return true
-		l65:
-			position, tokenIndex, depth = position65, tokenIndex65, depth65
+		l130:
+			position, tokenIndex, depth = position130, tokenIndex130, depth130
 			return false
 		},
-		/* 9 QuotedString <- <('"' <(!'"' .)*> '"' Action21)> */
+		/* 22 QuotedString <- <('"' <(('\\' .) / (!('"' / '\\') .))*> (('"' Action24) / ExpectQuote))> */
 		func() bool {
-			position75, tokenIndex75, depth75 := position, tokenIndex, depth
+			position140, tokenIndex140, depth140 := position, tokenIndex, depth
 			{
-				position76 := position
+				position141 := position
 				depth++
 				if buffer[position] != rune('"') {
-					goto l75
+					goto l140
 				}
 				position++
 				{
-					position77 := position
+					position142 := position
 					depth++
-				l78:
+				l143:
 					{
-						position79, tokenIndex79, depth79 := position, tokenIndex, depth
+						position144, tokenIndex144, depth144 := position, tokenIndex, depth
 						{
-							position80, tokenIndex80, depth80 := position, tokenIndex, depth
-							if buffer[position] != rune('"') {
-								goto l80
+							position145, tokenIndex145, depth145 := position, tokenIndex, depth
+							if buffer[position] != rune('\\') {
+								goto l146
 							}
 							position++
-							goto l79
-						l80:
-							position, tokenIndex, depth = position80, tokenIndex80, depth80
-						}
-						if !matchDot() {
-							goto l79
+							if !matchDot() {
+								goto l146
+							}
+							goto l145
+						l146:
+							position, tokenIndex, depth = position145, tokenIndex145, depth145
+							{
+								position147, tokenIndex147, depth147 := position, tokenIndex, depth
+								{
+									position148, tokenIndex148, depth148 := position, tokenIndex, depth
+									if buffer[position] != rune('"') {
+										goto l149
+									}
+									position++
+									goto l148
+								l149:
+									position, tokenIndex, depth = position148, tokenIndex148, depth148
+									if buffer[position] != rune('\\') {
+										goto l147
+									}
+									position++
+								}
+							l148:
+								goto l144
+							l147:
+								position, tokenIndex, depth = position147, tokenIndex147, depth147
+							}
+							if !matchDot() {
+								goto l144
+							}
 						}
-						goto l78
-					l79:
-						position, tokenIndex, depth = position79, tokenIndex79, depth79
+					l145:
+						goto l143
+					l144:
+						position, tokenIndex, depth = position144, tokenIndex144, depth144
 					}
 					depth--
-					add(rulePegText, position77)
+					add(rulePegText, position142)
 				}
-				if buffer[position] != rune('"') {
-					goto l75
-				}
-				position++
-				if !_rules[ruleAction21]() {
-					goto l75
+				{
+					position150, tokenIndex150, depth150 := position, tokenIndex, depth
+					if buffer[position] != rune('"') {
+						goto l151
+					}
+					position++
+					if !_rules[ruleAction24]() {
+						goto l151
+					}
+					goto l150
+				l151:
+					position, tokenIndex, depth = position150, tokenIndex150, depth150
+					if !_rules[ruleExpectQuote]() {
+						goto l140
+					}
 				}
+			l150:
 				depth--
-				add(ruleQuotedString, position76)
+				add(ruleQuotedString, position141)
 			}
 			return true
-		l75:
-			position, tokenIndex, depth = position75, tokenIndex75, depth75
+		l140:
+			position, tokenIndex, depth = position140, tokenIndex140, depth140
 			return false
 		},
-		/* 10 Integer <- <(<([1-9] [0-9]*)> Action22)> */
+		/* 23 Integer <- <(<('-'? ('0' / ([1-9] [0-9]*)))> Action25)> */
 		func() bool {
-			position81, tokenIndex81, depth81 := position, tokenIndex, depth
+			position152, tokenIndex152, depth152 := position, tokenIndex, depth
 			{
-				position82 := position
+				position153 := position
 				depth++
 				{
-					position83 := position
+					position154 := position
 					depth++
-					if c := buffer[position]; c < rune('1') || c > rune('9') {
-						goto l81
+					{
+						position155, tokenIndex155, depth155 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l155
+						}
+						position++
+						goto l156
+					l155:
+						position, tokenIndex, depth = position155, tokenIndex155, depth155
 					}
-					position++
-				l84:
+				l156:
 					{
-						position85, tokenIndex85, depth85 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l85
+						position157, tokenIndex157, depth157 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l158
+						}
+						position++
+						goto l157
+					l158:
+						position, tokenIndex, depth = position157, tokenIndex157, depth157
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l152
 						}
 						position++
-						goto l84
-					l85:
-						position, tokenIndex, depth = position85, tokenIndex85, depth85
+					l159:
+						{
+							position160, tokenIndex160, depth160 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l160
+							}
+							position++
+							goto l159
+						l160:
+							position, tokenIndex, depth = position160, tokenIndex160, depth160
+						}
 					}
+				l157:
 					depth--
-					add(rulePegText, position83)
+					add(rulePegText, position154)
 				}
-				if !_rules[ruleAction22]() {
-					goto l81
+				if !_rules[ruleAction25]() {
+					goto l152
 				}
 				depth--
-				add(ruleInteger, position82)
+				add(ruleInteger, position153)
 			}
 			return true
-		l81:
-			position, tokenIndex, depth = position81, tokenIndex81, depth81
+		l152:
+			position, tokenIndex, depth = position152, tokenIndex152, depth152
 			return false
 		},
-		/* 11 Float <- <(<([1-9] [0-9]* '.' [0-9]+)> Action23)> */
+		/* 24 Float <- <(<('-'? ('0' / ([1-9] [0-9]*)) '.' [0-9]+)> Action26)> */
 		func() bool {
-			position86, tokenIndex86, depth86 := position, tokenIndex, depth
+			position161, tokenIndex161, depth161 := position, tokenIndex, depth
 			{
-				position87 := position
+				position162 := position
 				depth++
 				{
-					position88 := position
+					position163 := position
 					depth++
-					if c := buffer[position]; c < rune('1') || c > rune('9') {
-						goto l86
+					{
+						position164, tokenIndex164, depth164 := position, tokenIndex, depth
+						if buffer[position] != rune('-') {
+							goto l164
+						}
+						position++
+						goto l165
+					l164:
+						position, tokenIndex, depth = position164, tokenIndex164, depth164
 					}
-					position++
-				l89:
+				l165:
 					{
-						position90, tokenIndex90, depth90 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l90
+						position166, tokenIndex166, depth166 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l167
+						}
+						position++
+						goto l166
+					l167:
+						position, tokenIndex, depth = position166, tokenIndex166, depth166
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l161
 						}
 						position++
-						goto l89
-					l90:
-						position, tokenIndex, depth = position90, tokenIndex90, depth90
+					l168:
+						{
+							position169, tokenIndex169, depth169 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l169
+							}
+							position++
+							goto l168
+						l169:
+							position, tokenIndex, depth = position169, tokenIndex169, depth169
+						}
 					}
+				l166:
 					if buffer[position] != rune('.') {
-						goto l86
+						goto l161
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l86
+						goto l161
 					}
 					position++
-				l91:
+				l170:
 					{
-						position92, tokenIndex92, depth92 := position, tokenIndex, depth
+						position171, tokenIndex171, depth171 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l92
+							goto l171
 						}
 						position++
-						goto l91
-					l92:
-						position, tokenIndex, depth = position92, tokenIndex92, depth92
+						goto l170
+					l171:
+						position, tokenIndex, depth = position171, tokenIndex171, depth171
 					}
 					depth--
-					add(rulePegText, position88)
+					add(rulePegText, position163)
 				}
-				if !_rules[ruleAction23]() {
-					goto l86
+				if !_rules[ruleAction26]() {
+					goto l161
 				}
 				depth--
-				add(ruleFloat, position87)
+				add(ruleFloat, position162)
 			}
 			return true
-		l86:
-			position, tokenIndex, depth = position86, tokenIndex86, depth86
+		l161:
+			position, tokenIndex, depth = position161, tokenIndex161, depth161
 			return false
 		},
-		/* 12 Bool <- <(('t' 'r' 'u' 'e' Action24) / ('f' 'a' 'l' 's' 'e' Action25))> */
+		/* 25 Bool <- <(('t' 'r' 'u' 'e' Action27) / ('f' 'a' 'l' 's' 'e' Action28))> */
 		func() bool {
-			position93, tokenIndex93, depth93 := position, tokenIndex, depth
+			position172, tokenIndex172, depth172 := position, tokenIndex, depth
 			{
-				position94 := position
+				position173 := position
 				depth++
 				{
-					position95, tokenIndex95, depth95 := position, tokenIndex, depth
+					position174, tokenIndex174, depth174 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l96
+						goto l175
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l96
+						goto l175
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l96
+						goto l175
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l96
+						goto l175
 					}
 					position++
-					if !_rules[ruleAction24]() {
-						goto l96
+					if !_rules[ruleAction27]() {
+						goto l175
 					}
-					goto l95
-				l96:
-					position, tokenIndex, depth = position95, tokenIndex95, depth95
+					goto l174
+				l175:
+					position, tokenIndex, depth = position174, tokenIndex174, depth174
 					if buffer[position] != rune('f') {
-						goto l93
+						goto l172
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l93
+						goto l172
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l93
+						goto l172
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l93
+						goto l172
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l93
+						goto l172
 					}
 					position++
-					if !_rules[ruleAction25]() {
-						goto l93
+					if !_rules[ruleAction28]() {
+						goto l172
 					}
 				}
-			l95:
+			l174:
 				depth--
-				add(ruleBool, position94)
+				add(ruleBool, position173)
 			}
 			return true
-		l93:
-			position, tokenIndex, depth = position93, tokenIndex93, depth93
+		l172:
+			position, tokenIndex, depth = position172, tokenIndex172, depth172
 			return false
 		},
-		/* 13 Spacing <- <(Space / Comment)*> */
+		/* 26 Spacing <- <(Space / Comment)*> */
 		func() bool {
 			{
-				position98 := position
+				position177 := position
 				depth++
-			l99:
+			l178:
 				{
-					position100, tokenIndex100, depth100 := position, tokenIndex, depth
+					position179, tokenIndex179, depth179 := position, tokenIndex, depth
 					{
-						position101, tokenIndex101, depth101 := position, tokenIndex, depth
+						position180, tokenIndex180, depth180 := position, tokenIndex, depth
 						if !_rules[ruleSpace]() {
-							goto l102
+							goto l181
 						}
-						goto l101
-					l102:
-						position, tokenIndex, depth = position101, tokenIndex101, depth101
+						goto l180
+					l181:
+						position, tokenIndex, depth = position180, tokenIndex180, depth180
 						if !_rules[ruleComment]() {
-							goto l100
+							goto l179
 						}
 					}
-				l101:
-					goto l99
-				l100:
-					position, tokenIndex, depth = position100, tokenIndex100, depth100
+				l180:
+					goto l178
+				l179:
+					position, tokenIndex, depth = position179, tokenIndex179, depth179
 				}
 				depth--
-				add(ruleSpacing, position98)
+				add(ruleSpacing, position177)
 			}
 			return true
 		},
-		/* 14 Comment <- <('#' (!EndOfLine .)*)> */
+		/* 27 Comment <- <('#' (!EndOfLine .)*)> */
 		func() bool {
-			position103, tokenIndex103, depth103 := position, tokenIndex, depth
+			position182, tokenIndex182, depth182 := position, tokenIndex, depth
 			{
-				position104 := position
+				position183 := position
 				depth++
 				if buffer[position] != rune('#') {
-					goto l103
+					goto l182
 				}
 				position++
-			l105:
+			l184:
 				{
-					position106, tokenIndex106, depth106 := position, tokenIndex, depth
+					position185, tokenIndex185, depth185 := position, tokenIndex, depth
 					{
-						position107, tokenIndex107, depth107 := position, tokenIndex, depth
+						position186, tokenIndex186, depth186 := position, tokenIndex, depth
 						if !_rules[ruleEndOfLine]() {
-							goto l107
+							goto l186
 						}
-						goto l106
-					l107:
-						position, tokenIndex, depth = position107, tokenIndex107, depth107
+						goto l185
+					l186:
+						position, tokenIndex, depth = position186, tokenIndex186, depth186
 					}
 					if !matchDot() {
-						goto l106
+						goto l185
 					}
-					goto l105
-				l106:
-					position, tokenIndex, depth = position106, tokenIndex106, depth106
+					goto l184
+				l185:
+					position, tokenIndex, depth = position185, tokenIndex185, depth185
 				}
 				depth--
-				add(ruleComment, position104)
+				add(ruleComment, position183)
 			}
 			return true
-		l103:
-			position, tokenIndex, depth = position103, tokenIndex103, depth103
+		l182:
+			position, tokenIndex, depth = position182, tokenIndex182, depth182
 			return false
 		},
-		/* 15 Space <- <(' ' / '\t' / EndOfLine)> */
+		/* 28 Space <- <(' ' / '\t' / EndOfLine)> */
 		func() bool {
-			position108, tokenIndex108, depth108 := position, tokenIndex, depth
+			position187, tokenIndex187, depth187 := position, tokenIndex, depth
 			{
-				position109 := position
+				position188 := position
 				depth++
 				{
-					position110, tokenIndex110, depth110 := position, tokenIndex, depth
+					position189, tokenIndex189, depth189 := position, tokenIndex, depth
 					if buffer[position] != rune(' ') {
-						goto l111
+						goto l190
 					}
 					position++
-					goto l110
-				l111:
-					position, tokenIndex, depth = position110, tokenIndex110, depth110
+					goto l189
+				l190:
+					position, tokenIndex, depth = position189, tokenIndex189, depth189
 					if buffer[position] != rune('\t') {
-						goto l112
+						goto l191
 					}
 					position++
-					goto l110
-				l112:
-					position, tokenIndex, depth = position110, tokenIndex110, depth110
+					goto l189
+				l191:
+					position, tokenIndex, depth = position189, tokenIndex189, depth189
 					if !_rules[ruleEndOfLine]() {
-						goto l108
+						goto l187
 					}
 				}
-			l110:
+			l189:
 				depth--
-				add(ruleSpace, position109)
+				add(ruleSpace, position188)
 			}
 			return true
-		l108:
-			position, tokenIndex, depth = position108, tokenIndex108, depth108
+		l187:
+			position, tokenIndex, depth = position187, tokenIndex187, depth187
 			return false
 		},
-		/* 16 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		/* 29 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
 		func() bool {
-			position113, tokenIndex113, depth113 := position, tokenIndex, depth
+			position192, tokenIndex192, depth192 := position, tokenIndex, depth
 			{
-				position114 := position
+				position193 := position
 				depth++
 				{
-					position115, tokenIndex115, depth115 := position, tokenIndex, depth
+					position194, tokenIndex194, depth194 := position, tokenIndex, depth
 					if buffer[position] != rune('\r') {
-						goto l116
+						goto l195
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l116
+						goto l195
 					}
 					position++
-					goto l115
-				l116:
-					position, tokenIndex, depth = position115, tokenIndex115, depth115
+					goto l194
+				l195:
+					position, tokenIndex, depth = position194, tokenIndex194, depth194
 					if buffer[position] != rune('\n') {
-						goto l117
+						goto l196
 					}
 					position++
-					goto l115
-				l117:
-					position, tokenIndex, depth = position115, tokenIndex115, depth115
+					goto l194
+				l196:
+					position, tokenIndex, depth = position194, tokenIndex194, depth194
 					if buffer[position] != rune('\r') {
-						goto l113
+						goto l192
 					}
 					position++
 				}
-			l115:
+			l194:
 				depth--
-				add(ruleEndOfLine, position114)
+				add(ruleEndOfLine, position193)
 			}
 			return true
-		l113:
-			position, tokenIndex, depth = position113, tokenIndex113, depth113
+		l192:
+			position, tokenIndex, depth = position192, tokenIndex192, depth192
 			return false
 		},
-		/* 18 Action0 <- <{ p.reduceAnd() }> */
+		/* 31 Action0 <- <{ p.reduceAnd(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction0, position)
 			}
 			return true
 		},
-		/* 19 Action1 <- <{ p.finalize() }> */
+		/* 32 Action1 <- <{ p.finalize() }> */
 		func() bool {
 			{
 				add(ruleAction1, position)
 			}
 			return true
 		},
-		/* 20 Action2 <- <{ p.pushOr() }> */
+		/* 33 Action2 <- <{ p.pushOr(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction2, position)
 			}
 			return true
 		},
-		/* 21 Action3 <- <{ p.pushOperatorExpr() }> */
+		/* 34 Action3 <- <{ p.pushOperatorExpr(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction3, position)
 			}
 			return true
 		},
-		/* 22 Action4 <- <{ p.pushColonExpr()    }> */
+		/* 35 Action4 <- <{ p.enter(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction4, position)
 			}
 			return true
 		},
-		/* 23 Action5 <- <{ p.pushNewState() }> */
+		/* 36 Action5 <- <{ p.pushColonExpr(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction5, position)
 			}
 			return true
 		},
-		/* 24 Action6 <- <{ p.reduceAnd() }> */
+		/* 37 Action6 <- <{ p.pushNewState(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction6, position)
 			}
 			return true
 		},
-		/* 25 Action7 <- <{ p.popNewState() }> */
+		/* 38 Action7 <- <{ p.reduceAnd(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction7, position)
 			}
 			return true
 		},
-		/* 26 Action8 <- <{ p.pushNot() }> */
+		/* 39 Action8 <- <{ p.popNewState() }> */
 		func() bool {
 			{
 				add(ruleAction8, position)
 			}
 			return true
 		},
-		/* 27 Action9 <- <{ p.pushKeywordExpr() }> */
+		/* 40 Action9 <- <{ p.enter(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction9, position)
 			}
 			return true
 		},
-		nil,
-		/* 29 Action10 <- <{ p.pushProperty(buffer[begin:end]) }> */
+		/* 41 Action10 <- <{ p.pushNot(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction10, position)
 			}
 			return true
 		},
-		/* 30 Action11 <- <{ p.pushOperator(ast.OpEq)  }> */
+		/* 42 Action11 <- <{ p.pushStemKeywordExpr(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction11, position)
 			}
 			return true
 		},
-		/* 31 Action12 <- <{ p.pushOperator(ast.OpNeq) }> */
+		/* 43 Action12 <- <{ p.pushKeywordExpr(token.begin) }> */
 		func() bool {
 			{
 				add(ruleAction12, position)
 			}
 			return true
 		},
-		/* 32 Action13 <- <{ p.pushOperator(ast.OpNeq) }> */
+		nil,
+		/* 45 Action13 <- <{ p.pushProperty(text) }> */
 		func() bool {
 			{
 				add(ruleAction13, position)
 			}
 			return true
 		},
-		/* 33 Action14 <- <{ p.pushOperator(ast.OpLt)  }> */
+		/* 46 Action14 <- <{ p.pushOperator(ast.OpEq)  }> */
 		func() bool {
 			{
 				add(ruleAction14, position)
 			}
 			return true
 		},
-		/* 34 Action15 <- <{ p.pushOperator(ast.OpLe) }> */
+		/* 47 Action15 <- <{ p.pushOperator(ast.OpNeq) }> */
 		func() bool {
 			{
 				add(ruleAction15, position)
 			}
 			return true
 		},
-		/* 35 Action16 <- <{ p.pushOperator(ast.OpGt)  }> */
+		/* 48 Action16 <- <{ p.pushOperator(ast.OpNeq) }> */
 		func() bool {
 			{
 				add(ruleAction16, position)
 			}
 			return true
 		},
-		/* 36 Action17 <- <{ p.pushOperator(ast.OpGe) }> */
+		/* 49 Action17 <- <{ p.pushOperator(ast.OpLe)  }> */
 		func() bool {
 			{
 				add(ruleAction17, position)
 			}
 			return true
 		},
-		/* 37 Action18 <- <{ p.pushTimeValue(time.RFC3339, buffer[begin:end]) }> */
+		/* 50 Action18 <- <{ p.pushOperator(ast.OpLt)  }> */
 		func() bool {
 			{
 				add(ruleAction18, position)
 			}
 			return true
 		},
-		/* 38 Action19 <- <{ p.pushTimeValue("2006-01-02", buffer[begin:end]) }> */
+		/* 51 Action19 <- <{ p.pushOperator(ast.OpGe)  }> */
 		func() bool {
 			{
 				add(ruleAction19, position)
 			}
 			return true
 		},
-		/* 39 Action20 <- <{ p.pushStringValue(buffer[begin:end]) }> */
+		/* 52 Action20 <- <{ p.pushOperator(ast.OpGt)  }> */
 		func() bool {
 			{
 				add(ruleAction20, position)
 			}
 			return true
 		},
-		/* 40 Action21 <- <{ p.pushStringValue(buffer[begin:end]) }> */
+		/* 53 Action21 <- <{ p.pushTimeValue(begin, time.RFC3339, text) }> */
 		func() bool {
 			{
 				add(ruleAction21, position)
 			}
 			return true
 		},
-		/* 41 Action22 <- <{ p.pushIntegerValue(buffer[begin:end]) }> */
+		/* 54 Action22 <- <{ p.pushTimeValue(begin, "2006-01-02", text) }> */
 		func() bool {
 			{
 				add(ruleAction22, position)
 			}
 			return true
 		},
-		/* 42 Action23 <- <{ p.pushFloatValue(buffer[begin:end]) }> */
+		/* 55 Action23 <- <{ p.pushStringValue(text) }> */
 		func() bool {
 			{
 				add(ruleAction23, position)
 			}
 			return true
 		},
-		/* 43 Action24 <- <{ p.pushBoolValue(true) }> */
+		/* 56 Action24 <- <{ p.pushQuotedStringValue(text) }> */
 		func() bool {
 			{
 				add(ruleAction24, position)
 			}
 			return true
 		},
-		/* 44 Action25 <- <{ p.pushBoolValue(false) }> */
+		/* 57 Action25 <- <{ p.pushIntegerValue(begin, text) }> */
 		func() bool {
 			{
 				add(ruleAction25, position)
 			}
 			return true
 		},
+		/* 58 Action26 <- <{ p.pushFloatValue(begin, text) }> */
+		func() bool {
+			{
+				add(ruleAction26, position)
+			}
+			return true
+		},
+		/* 59 Action27 <- <{ p.pushBoolValue(true) }> */
+		func() bool {
+			{
+				add(ruleAction27, position)
+			}
+			return true
+		},
+		/* 60 Action28 <- <{ p.pushBoolValue(false) }> */
+		func() bool {
+			{
+				add(ruleAction28, position)
+			}
+			return true
+		},
 	}
 	p.rules = _rules
 }
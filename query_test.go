@@ -16,6 +16,27 @@ func mustParseTime(s string) time.Time {
 	return v
 }
 
+func TestParse_Literals(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected ast.Expr
+	}{
+		{`"say \"hello\""`, &ast.KeywordExpr{Value: ast.StringValue(`say "hello"`)}},
+		{`"back\\slash"`, &ast.KeywordExpr{Value: ast.StringValue(`back\slash`)}},
+		{`"a\b"`, &ast.KeywordExpr{Value: ast.StringValue(`ab`)}},
+		{`diff > -3`, &ast.OperatorExpr{Property: "diff", Operator: ast.OpGt, Value: ast.IntegerValue(-3)}},
+		{`stock != 0`, &ast.OperatorExpr{Property: "stock", Operator: ast.OpNeq, Value: ast.IntegerValue(0)}},
+		{`price <= 0.5`, &ast.OperatorExpr{Property: "price", Operator: ast.OpLe, Value: ast.FloatValue(0.5)}},
+		{`temp >= -1.25`, &ast.OperatorExpr{Property: "temp", Operator: ast.OpGe, Value: ast.FloatValue(-1.25)}},
+	}
+	for _, c := range cases {
+		expr, err := Parse(c.Input)
+		if assert.NoError(t, err, c.Input) {
+			assert.Equal(t, c.Expected, expr, c.Input)
+		}
+	}
+}
+
 func TestParse(t *testing.T) {
 	t.Run("", func(t *testing.T) {
 		s := `blue`
@@ -39,6 +60,26 @@ func TestParse(t *testing.T) {
 			},
 		}, expr, s)
 	})
+	t.Run("", func(t *testing.T) {
+		s := `~dog title:~"running shoes"`
+		expr, err := Parse(s)
+		if !assert.NoError(t, err, s) {
+			return
+		}
+		assert.Equal(t, ast.And{
+			&ast.KeywordExpr{
+				Value: ast.StringValue("dog"),
+				Stem:  true,
+			},
+			&ast.ColonExpr{
+				Property: "title",
+				Expr: &ast.KeywordExpr{
+					Value: ast.StringValue("running shoes"),
+					Stem:  true,
+				},
+			},
+		}, expr, s)
+	})
 	t.Run("", func(t *testing.T) {
 		s := `blue OR red`
 		expr, err := Parse(s)
@@ -0,0 +1,152 @@
+package searchquery
+
+import (
+	"fmt"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// Schema describes the properties which queries may refer.
+type Schema struct {
+	Fields []Field
+}
+
+type Field struct {
+	// Name is the property name as written in a query, e.g. "users.name".
+	Name string
+
+	// Kind is the kind of values stored in the field, zero accepts any value.
+	Kind ast.Kind
+
+	// Values enumerates the allowed values if not empty.
+	Values []string
+}
+
+type SchemaError struct {
+	Property string
+
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: property %q: %s", pkgName, e.Property, e.Message)
+}
+
+// Field returns the field named name.
+func (s *Schema) Field(name string) (*Field, bool) {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// Check reports a *SchemaError if expr refers to an unknown property, or compares a property with an
+// incompatible value or operator.
+func (s *Schema) Check(expr ast.Expr) error {
+	return s.check(expr, nil)
+}
+
+func (s *Schema) check(expr ast.Expr, field *Field) error {
+	switch e := expr.(type) {
+	case ast.And:
+		for _, v := range e {
+			if err := s.check(v, field); err != nil {
+				return err
+			}
+		}
+	case ast.Or:
+		for _, v := range e {
+			if err := s.check(v, field); err != nil {
+				return err
+			}
+		}
+	case *ast.Not:
+		return s.check(e.Expr, field)
+	case *ast.OperatorExpr:
+		f, err := s.lookup(e.Property)
+		if err != nil {
+			return err
+		}
+		return f.check(e.Operator, e.Value)
+	case *ast.ColonExpr:
+		f, err := s.lookup(e.Property)
+		if err != nil {
+			return err
+		}
+		return s.check(e.Expr, f)
+	case *ast.KeywordExpr:
+		// keywords outside of property:expr search over all fields
+		if field == nil {
+			return nil
+		}
+		return field.check(ast.OpEq, e.Value)
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+func (s *Schema) lookup(name string) (*Field, error) {
+	f, ok := s.Field(name)
+	if !ok {
+		return nil, &SchemaError{Property: name, Message: "unknown property"}
+	}
+	return f, nil
+}
+
+// Operators returns the operators applicable to the field. Bool fields and fields enumerating Values take
+// only = and !=, as their values are not ordered.
+func (f *Field) Operators() []ast.Op {
+	if f.Kind == ast.KindBool || len(f.Values) > 0 {
+		return []ast.Op{ast.OpEq, ast.OpNeq}
+	}
+	return []ast.Op{ast.OpEq, ast.OpNeq, ast.OpLt, ast.OpLe, ast.OpGt, ast.OpGe}
+}
+
+func (f *Field) check(op ast.Op, v ast.Value) error {
+	if !f.acceptsOperator(op) {
+		return &SchemaError{Property: f.Name, Message: fmt.Sprintf("operator %v is not applicable to %v", op, f.Kind)}
+	}
+	if !f.acceptsValue(v) {
+		return &SchemaError{Property: f.Name, Message: fmt.Sprintf("%v value %v is not applicable to %v", v.Kind(), v.Raw(), f.Kind)}
+	}
+	if len(f.Values) > 0 {
+		s, _ := ast.AsString(v)
+		for _, allowed := range f.Values {
+			if s == allowed {
+				return nil
+			}
+		}
+		return &SchemaError{Property: f.Name, Message: fmt.Sprintf("value %q is not one of %q", s, f.Values)}
+	}
+	return nil
+}
+
+func (f *Field) acceptsOperator(op ast.Op) bool {
+	for _, v := range f.Operators() {
+		if v == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Field) acceptsValue(v ast.Value) bool {
+	switch f.Kind {
+	case 0, ast.KindString:
+		// every value can be compared as text
+		return true
+	case ast.KindInteger, ast.KindFloat:
+		return v.Kind().IsNumeric()
+	case ast.KindTime:
+		_, err := ast.AsTime(v)
+		return err == nil
+	case ast.KindBool:
+		_, err := ast.AsBool(v)
+		return err == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,50 @@
+package searchquery
+
+import (
+	"testing"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema_Check(t *testing.T) {
+	schema := &Schema{
+		Fields: []Field{
+			{Name: "title", Kind: ast.KindString},
+			{Name: "pages", Kind: ast.KindInteger},
+			{Name: "published", Kind: ast.KindTime},
+			{Name: "available", Kind: ast.KindBool},
+			{Name: "genre", Kind: ast.KindString, Values: []string{"fantasy", "horror"}},
+		},
+	}
+	for _, s := range []string{
+		`harry potter`,
+		`title:"Harry Potter" AND pages < 500.5`,
+		`published >= 1997-06-26 AND available = true`,
+		`genre:(fantasy OR horror)`,
+		`NOT genre = horror`,
+	} {
+		expr, err := Parse(s)
+		if !assert.NoError(t, err, s) {
+			continue
+		}
+		assert.NoError(t, schema.Check(expr), s)
+	}
+	for s, property := range map[string]string{
+		`author:rowling`:             "author",
+		`pages = many`:               "pages",
+		`published > yesterday`:      "published",
+		`available < true`:           "available",
+		`genre:(fantasy OR romance)`: "genre",
+		`genre < horror`:             "genre",
+	} {
+		expr, err := Parse(s)
+		if !assert.NoError(t, err, s) {
+			continue
+		}
+		err = schema.Check(expr)
+		if assert.IsType(t, &SchemaError{}, err, s) {
+			assert.Equal(t, property, err.(*SchemaError).Property, s)
+		}
+	}
+}
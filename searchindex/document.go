@@ -0,0 +1,68 @@
+package searchindex
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Atom is the value of a field which is matched as a whole, case insensitively.
+type Atom string
+
+// HTML is the value of a field which is tokenized as text after removing markup.
+type HTML string
+
+// GeoPoint is the value of a geo field, in degrees.
+type GeoPoint struct {
+	Lat, Lng float64
+}
+
+// Field is a named value of a document. Value is one of string for a text field, Atom, HTML, float64 for a
+// number field, time.Time for a date field, or GeoPoint.
+// A document can hold several fields of the same name, a query matches if any of them matches.
+type Field struct {
+	Name string
+
+	Value interface{}
+}
+
+type Document struct {
+	Fields []Field
+
+	// Rank orders results in descending order, the seconds since 2011-01-01 at Put by default as GAE does.
+	Rank int
+}
+
+var fieldNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func validateID(id string) error {
+	if len(id) > 500 {
+		return fmt.Errorf("%s: document id is longer than 500 bytes", pkgName)
+	}
+	if id[0] == '!' {
+		return fmt.Errorf("%s: document id %q starts with !", pkgName, id)
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return fmt.Errorf("%s: document id %q has non-printable or non-ASCII characters", pkgName, id)
+		}
+	}
+	return nil
+}
+
+func validateField(f *Field) error {
+	if len(f.Name) > 500 || !fieldNameRegexp.MatchString(f.Name) {
+		return fmt.Errorf("%s: invalid field name %q", pkgName, f.Name)
+	}
+	switch v := f.Value.(type) {
+	case string, Atom, HTML, float64, time.Time:
+		return nil
+	case GeoPoint:
+		if v.Lat < -90 || v.Lat > 90 || v.Lng < -180 || v.Lng > 180 {
+			return fmt.Errorf("%s: field %q: invalid geo point %v", pkgName, f.Name, v)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: field %q: unsupported value type %T", pkgName, f.Name, f.Value)
+	}
+}
@@ -0,0 +1,342 @@
+package searchindex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// ErrMissingField is returned by Eval if doc lacks a field which expr refers.
+var ErrMissingField = errors.New(pkgName + ": missing field")
+
+// earthRadius is the mean radius of the earth in meters, distances are in meters as GAE.
+const earthRadius = 6371010
+
+// Eval computes the field expression expr for doc, as parsed by searchquery.ParseFieldExpr.
+// A field refers to the first field of the name, _rank to the rank of doc, and _score is 0 as doc is not
+// scored out of a search.
+func Eval(expr ast.Scalar, doc *Document) (ast.Value, error) {
+	return (&evaluator{doc: doc, analyzer: analysis.Standard}).eval(expr)
+}
+
+type evaluator struct {
+	doc *Document
+
+	score float64
+
+	// analyzer analyzes text of snippets.
+	analyzer analysis.Analyzer
+}
+
+func (e *evaluator) eval(expr ast.Scalar) (ast.Value, error) {
+	switch x := expr.(type) {
+	case *ast.Literal:
+		return x.Value, nil
+	case *ast.FieldRef:
+		return e.field(x.Name)
+	case *ast.Neg:
+		v, err := e.eval(x.X)
+		if err != nil {
+			return nil, err
+		}
+		switch v := v.(type) {
+		case ast.IntegerValue:
+			if v == math.MinInt64 {
+				// -v overflows
+				return -ast.FloatValue(v), nil
+			}
+			return -v, nil
+		case ast.FloatValue:
+			return -v, nil
+		default:
+			return nil, fmt.Errorf("%s: can not negate %v", pkgName, v.Kind())
+		}
+	case *ast.BinaryExpr:
+		return e.binary(x)
+	case *ast.Call:
+		return e.call(x)
+	default:
+		return nil, fmt.Errorf("%s: unknown scalar type %T", pkgName, expr)
+	}
+}
+
+func (e *evaluator) field(name string) (ast.Value, error) {
+	switch name {
+	case "_rank":
+		return ast.IntegerValue(e.doc.Rank), nil
+	case "_score":
+		return ast.FloatValue(e.score), nil
+	}
+	for _, f := range e.doc.Fields {
+		if f.Name != name {
+			continue
+		}
+		switch v := f.Value.(type) {
+		case string:
+			return ast.StringValue(v), nil
+		case Atom:
+			return ast.StringValue(v), nil
+		case HTML:
+			return ast.StringValue(v), nil
+		case float64:
+			return ast.FloatValue(v), nil
+		case time.Time:
+			return ast.TimeValue(v), nil
+		default:
+			return nil, fmt.Errorf("%s: field %q of %T can only be used in distance", pkgName, name, v)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMissingField, name)
+}
+
+func (e *evaluator) binary(x *ast.BinaryExpr) (ast.Value, error) {
+	vx, err := e.eval(x.X)
+	if err != nil {
+		return nil, err
+	}
+	vy, err := e.eval(x.Y)
+	if err != nil {
+		return nil, err
+	}
+	ix, xInt := vx.(ast.IntegerValue)
+	iy, yInt := vy.(ast.IntegerValue)
+	if xInt && yInt {
+		// integers which overflow are computed as floats below
+		switch x.Op {
+		case ast.ArithAdd:
+			if r := ix + iy; (r > ix) == (iy > 0) {
+				return r, nil
+			}
+		case ast.ArithSub:
+			if r := ix - iy; (r < ix) == (iy > 0) {
+				return r, nil
+			}
+		case ast.ArithMul:
+			if r := ix * iy; ix == 0 || (r/ix == iy && !(ix == -1 && iy == math.MinInt64)) {
+				return r, nil
+			}
+		}
+	}
+	fx, err := number(vx)
+	if err != nil {
+		return nil, err
+	}
+	fy, err := number(vy)
+	if err != nil {
+		return nil, err
+	}
+	switch x.Op {
+	case ast.ArithAdd:
+		return ast.FloatValue(fx + fy), nil
+	case ast.ArithSub:
+		return ast.FloatValue(fx - fy), nil
+	case ast.ArithMul:
+		return ast.FloatValue(fx * fy), nil
+	case ast.ArithDiv:
+		if fy == 0 {
+			return nil, fmt.Errorf("%s: division by zero", pkgName)
+		}
+		return ast.FloatValue(fx / fy), nil
+	default:
+		return nil, fmt.Errorf("%s: invalid operator %v", pkgName, x.Op)
+	}
+}
+
+func (e *evaluator) call(x *ast.Call) (ast.Value, error) {
+	switch x.Func {
+	case "count":
+		name, err := fieldArg(x, 0)
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		for _, f := range e.doc.Fields {
+			if f.Name == name {
+				n++
+			}
+		}
+		return ast.IntegerValue(n), nil
+	case "distance":
+		if len(x.Args) != 2 {
+			return nil, fmt.Errorf("%s: wrong number of arguments to %s", pkgName, x.Func)
+		}
+		p1, err := e.geoPoint(x.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		p2, err := e.geoPoint(x.Args[1])
+		if err != nil {
+			return nil, err
+		}
+		return ast.FloatValue(distance(p1, p2)), nil
+	case "geopoint":
+		return nil, fmt.Errorf("%s: geopoint can only be used in distance", pkgName)
+	case "snippet":
+		return e.snippet(x)
+	}
+
+	// ints are the arguments if all of them are integers, which are computed exactly
+	args := make([]float64, len(x.Args))
+	ints := make([]ast.IntegerValue, len(x.Args))
+	allInt := true
+	for i, arg := range x.Args {
+		v, err := e.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		if iv, ok := v.(ast.IntegerValue); ok {
+			ints[i] = iv
+		} else {
+			allInt = false
+		}
+		if args[i], err = number(v); err != nil {
+			return nil, err
+		}
+	}
+	switch x.Func {
+	case "abs":
+		if err := arity(x, 1); err != nil {
+			return nil, err
+		}
+		if allInt && ints[0] != math.MinInt64 {
+			if ints[0] < 0 {
+				return -ints[0], nil
+			}
+			return ints[0], nil
+		}
+		return ast.FloatValue(math.Abs(args[0])), nil
+	case "log":
+		if err := arity(x, 1); err != nil {
+			return nil, err
+		}
+		if args[0] <= 0 {
+			return nil, fmt.Errorf("%s: log of non-positive number %v", pkgName, args[0])
+		}
+		return ast.FloatValue(math.Log(args[0])), nil
+	case "pow":
+		if err := arity(x, 2); err != nil {
+			return nil, err
+		}
+		return ast.FloatValue(math.Pow(args[0], args[1])), nil
+	case "max", "min":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s: wrong number of arguments to %s", pkgName, x.Func)
+		}
+		if allInt {
+			r := ints[0]
+			for _, v := range ints[1:] {
+				if (x.Func == "max") == (v > r) {
+					r = v
+				}
+			}
+			return r, nil
+		}
+		r := args[0]
+		for _, v := range args[1:] {
+			if x.Func == "max" {
+				r = math.Max(r, v)
+			} else {
+				r = math.Min(r, v)
+			}
+		}
+		return ast.FloatValue(r), nil
+	default:
+		return nil, fmt.Errorf("%s: unknown function %s", pkgName, x.Func)
+	}
+}
+
+func (e *evaluator) geoPoint(arg ast.Scalar) (GeoPoint, error) {
+	switch x := arg.(type) {
+	case *ast.FieldRef:
+		for _, f := range e.doc.Fields {
+			if p, ok := f.Value.(GeoPoint); ok && f.Name == x.Name {
+				return p, nil
+			}
+		}
+		return GeoPoint{}, fmt.Errorf("%w: %s", ErrMissingField, x.Name)
+	case *ast.Call:
+		if x.Func != "geopoint" || len(x.Args) != 2 {
+			break
+		}
+		var latlng [2]float64
+		for i, arg := range x.Args {
+			v, err := e.eval(arg)
+			if err != nil {
+				return GeoPoint{}, err
+			}
+			if latlng[i], err = number(v); err != nil {
+				return GeoPoint{}, err
+			}
+		}
+		return GeoPoint{Lat: latlng[0], Lng: latlng[1]}, nil
+	}
+	return GeoPoint{}, fmt.Errorf("%s: arguments of distance must be geo fields or geopoint", pkgName)
+}
+
+// distance returns the great-circle distance between p1 and p2 in meters.
+func distance(p1, p2 GeoPoint) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(p2.Lat - p1.Lat)
+	dLng := rad(p2.Lng - p1.Lng)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rad(p1.Lat))*math.Cos(rad(p2.Lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func number(v ast.Value) (float64, error) {
+	switch v := v.(type) {
+	case ast.IntegerValue:
+		return float64(v), nil
+	case ast.FloatValue:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("%s: %v is not a number", pkgName, v)
+	}
+}
+
+func arity(x *ast.Call, n int) error {
+	if len(x.Args) != n {
+		return fmt.Errorf("%s: wrong number of arguments to %s", pkgName, x.Func)
+	}
+	return nil
+}
+
+func fieldArg(x *ast.Call, i int) (string, error) {
+	if i >= len(x.Args) {
+		return "", fmt.Errorf("%s: wrong number of arguments to %s", pkgName, x.Func)
+	}
+	f, ok := x.Args[i].(*ast.FieldRef)
+	if !ok {
+		return "", fmt.Errorf("%s: argument %d of %s must be a field", pkgName, i+1, x.Func)
+	}
+	return f.Name, nil
+}
+
+// computeField computes expr as a field of name, snippets are HTML fields.
+func (e *evaluator) computeField(name string, expr ast.Scalar) (Field, bool) {
+	v, err := e.eval(expr)
+	if err != nil {
+		return Field{}, false
+	}
+	f := Field{Name: name}
+	switch v := v.(type) {
+	case ast.StringValue:
+		if call, ok := expr.(*ast.Call); ok && call.Func == "snippet" {
+			f.Value = HTML(v)
+		} else {
+			f.Value = string(v)
+		}
+	case ast.IntegerValue:
+		f.Value = float64(v)
+	case ast.FloatValue:
+		f.Value = float64(v)
+	case ast.TimeValue:
+		f.Value = time.Time(v)
+	default:
+		return Field{}, false
+	}
+	return f, true
+}
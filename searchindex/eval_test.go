@@ -0,0 +1,156 @@
+package searchindex
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	doc := &Document{Rank: 7, Fields: []Field{
+		{Name: "title", Value: "Harry Potter"},
+		{Name: "title_ja", Value: "ハリー・ポッターと賢者の石"},
+		{Name: "content", Value: HTML("<p>The boy who lived, Harry Potter, had never even heard of Hogwarts when the letters started dropping on the doormat at number four, Privet Drive.</p>")},
+		{Name: "price", Value: 9.5},
+		{Name: "qty", Value: float64(3)},
+		{Name: "tags", Value: Atom("fantasy")},
+		{Name: "tags", Value: Atom("novel")},
+		{Name: "store", Value: GeoPoint{Lat: 35.681236, Lng: 139.767125}},
+		{Name: "published", Value: time.Date(1997, 6, 26, 0, 0, 0, 0, time.UTC)},
+	}}
+	cases := []struct {
+		Input    string
+		Expected ast.Value
+	}{
+		{`price * qty`, ast.FloatValue(28.5)},
+		{`count(tags) + 1`, ast.IntegerValue(3)},
+		{`-count(tags) * 2`, ast.IntegerValue(-4)},
+		{`max(1, 3, 2)`, ast.IntegerValue(3)},
+		{`min(price, 2)`, ast.FloatValue(2)},
+		{`abs(-2)`, ast.IntegerValue(2)},
+		{`pow(2, 10)`, ast.FloatValue(1024)},
+		{`10 / 4`, ast.FloatValue(2.5)},
+		{`_rank`, ast.IntegerValue(7)},
+		{`title`, ast.StringValue("Harry Potter")},
+		{`published`, ast.TimeValue(time.Date(1997, 6, 26, 0, 0, 0, 0, time.UTC))},
+		{`snippet("potter", title)`, ast.StringValue("Harry <b>Potter</b>")},
+		{`snippet("hogwarts", content, 40)`, ast.StringValue("...heard of <b>Hogwarts</b> when the letters...")},
+		{`snippet("letters OR nothing", content, 30)`, ast.StringValue("...the <b>letters</b> started dropping...")},
+		{`snippet("~letter ~drop", content, 30)`, ast.StringValue("...the <b>letters</b> started <b>dropping</b>...")},
+		{`snippet("\"賢者\"", title_ja)`, ast.StringValue("ハリー・ポッターと<b>賢者</b>の石")},
+		{`snippet("\"ポッター\" \"賢者の石\"", title_ja)`, ast.StringValue("ハリー・<b>ポッター</b>と<b>賢者の石</b>")},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.ParseFieldExpr(c.Input)
+		if !assert.NoError(t, err, c.Input) {
+			continue
+		}
+		v, err := Eval(expr, doc)
+		if assert.NoError(t, err, c.Input) {
+			assert.Equal(t, c.Expected, v, c.Input)
+		}
+	}
+
+	t.Run("distance", func(t *testing.T) {
+		expr, err := searchquery.ParseFieldExpr(`distance(store, geopoint(34.702485, 135.495951))`)
+		require.NoError(t, err)
+		v, err := Eval(expr, doc)
+		if assert.NoError(t, err) {
+			assert.InDelta(t, 403000, float64(v.(ast.FloatValue)), 1000)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		const max = math.MaxInt64
+		cases := []struct {
+			Input    ast.Scalar
+			Expected ast.Value
+		}{
+			{&ast.BinaryExpr{Op: ast.ArithAdd, X: intLit(max), Y: intLit(1)}, ast.FloatValue(max + 1.0)},
+			{&ast.BinaryExpr{Op: ast.ArithAdd, X: intLit(max), Y: intLit(0)}, ast.IntegerValue(max)},
+			{&ast.BinaryExpr{Op: ast.ArithSub, X: intLit(-max), Y: intLit(2)}, ast.FloatValue(-max - 2.0)},
+			{&ast.BinaryExpr{Op: ast.ArithSub, X: intLit(-max), Y: intLit(1)}, ast.IntegerValue(math.MinInt64)},
+			{&ast.BinaryExpr{Op: ast.ArithMul, X: intLit(max), Y: intLit(2)}, ast.FloatValue(max * 2.0)},
+			{&ast.BinaryExpr{Op: ast.ArithMul, X: intLit(-1), Y: intLit(math.MinInt64)}, ast.FloatValue(max + 1.0)},
+			{&ast.BinaryExpr{Op: ast.ArithMul, X: intLit(math.MinInt64), Y: intLit(-1)}, ast.FloatValue(max + 1.0)},
+			{&ast.BinaryExpr{Op: ast.ArithMul, X: intLit(-3), Y: intLit(4)}, ast.IntegerValue(-12)},
+			{&ast.Neg{X: intLit(math.MinInt64)}, ast.FloatValue(max + 1.0)},
+			{&ast.Call{Func: "abs", Args: []ast.Scalar{intLit(math.MinInt64)}}, ast.FloatValue(max + 1.0)},
+			{&ast.Call{Func: "abs", Args: []ast.Scalar{intLit(-max)}}, ast.IntegerValue(max)},
+			{&ast.Call{Func: "max", Args: []ast.Scalar{intLit(max), intLit(max - 1)}}, ast.IntegerValue(max)},
+		}
+		for _, c := range cases {
+			v, err := Eval(c.Input, doc)
+			if assert.NoError(t, err, "%v", c.Input) {
+				assert.Equal(t, c.Expected, v, "%v", c.Input)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, s := range []string{
+			`missing`,
+			`price / 0`,
+			`log(0)`,
+			`title * 2`,
+			`-title`,
+			`store`,
+			`geopoint(1, 2)`,
+			`distance(title, store)`,
+			`snippet("x", price)`,
+			`snippet(1, title)`,
+			`snippet("x", title, 0)`,
+		} {
+			expr, err := searchquery.ParseFieldExpr(s)
+			if !assert.NoError(t, err, s) {
+				continue
+			}
+			_, err = Eval(expr, doc)
+			assert.Error(t, err, s)
+		}
+		_, err := Eval(&ast.FieldRef{Name: "missing"}, doc)
+		assert.True(t, errors.Is(err, ErrMissingField))
+	})
+}
+
+func intLit(i int64) ast.Scalar {
+	return &ast.Literal{Value: ast.IntegerValue(i)}
+}
+
+func TestIndex_SearchExpressions(t *testing.T) {
+	index := newTestIndex(t)
+	res, err := index.Search(`potter`, &SearchOptions{
+		IDsOnly: true,
+		Expressions: []FieldExpression{
+			{Name: "snippet", Expr: `snippet("potter", title)`},
+			{Name: "double", Expr: `pages * 2`},
+			{Name: "year", Expr: `published`},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 2)
+	assert.Equal(t, []Field{
+		{Name: "snippet", Value: HTML("Harry <b>Potter</b> and the Philosopher&#39;s Stone")},
+		{Name: "double", Value: float64(446)},
+		{Name: "year", Value: time.Date(1997, 6, 26, 10, 0, 0, 0, time.UTC)},
+	}, res.Results[0].Expressions)
+	// published is missing
+	assert.Equal(t, []Field{
+		{Name: "snippet", Value: HTML("Field of the <b>Potter</b>")},
+		{Name: "double", Value: float64(301)},
+	}, res.Results[1].Expressions)
+
+	for _, fe := range []FieldExpression{
+		{Name: "1x", Expr: `pages`},
+		{Name: "x", Expr: `pages DEFAULT 1`},
+	} {
+		_, err := index.Search(`potter`, &SearchOptions{Expressions: []FieldExpression{fe}})
+		assert.Error(t, err, "%v", fe)
+	}
+}
@@ -0,0 +1,264 @@
+// Package searchindex is an in-memory index which emulates indexes of the GAE Search API, so that queries can
+// be tested without the service.
+//
+//	index := searchindex.NewIndex()
+//	index.Put("1", &searchindex.Document{Fields: []searchindex.Field{
+//		{Name: "title", Value: "Harry Potter"},
+//		{Name: "pages", Value: float64(223)},
+//	}})
+//	res, err := index.Search("potter pages < 500", nil)
+package searchindex
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/searchindex"
+)
+
+// ErrNoSuchDocument is returned by Get for an unknown document id.
+var ErrNoSuchDocument = errors.New(pkgName + ": no such document")
+
+// rankEpoch is the origin of default ranks.
+var rankEpoch = time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Index is an in-memory index of documents.
+// An Index is safe for concurrent use.
+type Index struct {
+	parser *searchquery.Parser
+
+	analyzer analysis.Analyzer
+
+	mu sync.RWMutex
+
+	docs map[string]*entry
+}
+
+// entry is a stored document with its analyzed fields.
+type entry struct {
+	id string
+
+	doc *Document
+
+	fields []field
+}
+
+// NewIndex returns an empty index, which parses queries with opts and analyzes text by analysis.Standard.
+func NewIndex(opts ...searchquery.ParseOption) *Index {
+	return NewIndexWithAnalyzer(analysis.Standard, opts...)
+}
+
+// NewIndexWithAnalyzer returns an empty index, which parses queries with opts and analyzes text fields and
+// words of queries by analyzer.
+func NewIndexWithAnalyzer(analyzer analysis.Analyzer, opts ...searchquery.ParseOption) *Index {
+	return &Index{
+		parser:   searchquery.NewParser(opts...),
+		analyzer: analyzer,
+		docs:     map[string]*entry{},
+	}
+}
+
+// Put stores doc as id, replacing a document of the same id. A new id is generated if id is empty.
+func (idx *Index) Put(id string, doc *Document) (string, error) {
+	if doc == nil {
+		return "", fmt.Errorf("%s: nil document", pkgName)
+	}
+	if id == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		id = hex.EncodeToString(b)
+	}
+	if err := validateID(id); err != nil {
+		return "", err
+	}
+	e := &entry{
+		id:  id,
+		doc: &Document{Rank: doc.Rank},
+	}
+	if e.doc.Rank == 0 {
+		e.doc.Rank = int(time.Since(rankEpoch) / time.Second)
+	}
+	for i := range doc.Fields {
+		if err := validateField(&doc.Fields[i]); err != nil {
+			return "", err
+		}
+		e.doc.Fields = append(e.doc.Fields, doc.Fields[i])
+		e.fields = append(e.fields, analyze(&doc.Fields[i], idx.analyzer))
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.docs[id] = e
+	return id, nil
+}
+
+// Get returns a copy of the document of id.
+func (idx *Index) Get(id string) (*Document, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	e, ok := idx.docs[id]
+	if !ok {
+		return nil, ErrNoSuchDocument
+	}
+	return e.copyDocument(), nil
+}
+
+// Delete removes the document of id, it is not an error if there is no such document.
+func (idx *Index) Delete(id string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.docs, id)
+	return nil
+}
+
+func (e *entry) copyDocument() *Document {
+	return &Document{
+		Fields: append([]Field(nil), e.doc.Fields...),
+		Rank:   e.doc.Rank,
+	}
+}
+
+type SearchOptions struct {
+	// Limit is the maximum number of results, 20 by default as GAE.
+	Limit int
+
+	// Offset is the number of results to skip.
+	Offset int
+
+	// IDsOnly omits documents from results.
+	IDsOnly bool
+
+	// Expressions are computed for each result.
+	Expressions []FieldExpression
+
+	// Scoring orders results in descending order of relevance to the query before rank, and sets scores of
+	// results.
+	Scoring *ScoringOptions
+}
+
+// FieldExpression is a field computed by a field expression, see searchquery.ParseFieldExpr.
+type FieldExpression struct {
+	Name string
+
+	Expr string
+}
+
+type SearchResult struct {
+	// Count is the number of matched documents regardless of Limit and Offset.
+	Count int
+
+	Results []Result
+}
+
+type Result struct {
+	ID string
+
+	// Document is nil if SearchOptions.IDsOnly is set.
+	Document *Document
+
+	// Expressions are the fields computed by SearchOptions.Expressions, except those failed to compute,
+	// e.g. of missing fields.
+	Expressions []Field
+
+	// Score is the relevance of the document if SearchOptions.Scoring is set, which is _score of expressions.
+	Score float64
+}
+
+// Search returns documents matching query in descending order of rank, or of score with scoring, ties are
+// ordered by id. An empty query matches all documents.
+func (idx *Index) Search(query string, opts *SearchOptions) (*SearchResult, error) {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	var (
+		m    matcher
+		expr ast.Expr
+	)
+	if strings.TrimSpace(query) != "" {
+		var err error
+		if expr, err = idx.parser.Parse(query); err != nil {
+			return nil, err
+		}
+		m = compile(expr, dateExprs(query, expr), idx.analyzer)
+	}
+	if sc := opts.Scoring; sc != nil && sc.B != nil && (*sc.B < 0 || *sc.B > 1) {
+		return nil, fmt.Errorf("%s: B of scoring must be between 0 and 1, got %v", pkgName, *sc.B)
+	}
+	if opts.Offset < 0 {
+		return nil, fmt.Errorf("%s: offset must not be negative, got %d", pkgName, opts.Offset)
+	}
+	exprs := make([]ast.Scalar, len(opts.Expressions))
+	for i, fe := range opts.Expressions {
+		if !fieldNameRegexp.MatchString(fe.Name) {
+			return nil, fmt.Errorf("%s: invalid field name %q", pkgName, fe.Name)
+		}
+		expr, err := searchquery.ParseFieldExpr(fe.Expr)
+		if err != nil {
+			return nil, err
+		}
+		exprs[i] = expr
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	var (
+		hits   []*entry
+		all    []*entry
+		scores = map[*entry]float64{}
+	)
+	for _, e := range idx.docs {
+		all = append(all, e)
+		if m == nil || m(e.fields) {
+			hits = append(hits, e)
+		}
+	}
+	if opts.Scoring != nil {
+		sc := newScorer(opts.Scoring, expr, all, idx.analyzer)
+		for _, e := range hits {
+			scores[e] = sc.score(e)
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if scores[hits[i]] != scores[hits[j]] {
+			return scores[hits[i]] > scores[hits[j]]
+		}
+		if hits[i].doc.Rank != hits[j].doc.Rank {
+			return hits[i].doc.Rank > hits[j].doc.Rank
+		}
+		return hits[i].id < hits[j].id
+	})
+
+	res := &SearchResult{Count: len(hits)}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	for i := opts.Offset; i < len(hits) && len(res.Results) < limit; i++ {
+		r := Result{ID: hits[i].id, Score: scores[hits[i]]}
+		if !opts.IDsOnly {
+			r.Document = hits[i].copyDocument()
+		}
+		ev := &evaluator{doc: hits[i].doc, score: r.Score, analyzer: idx.analyzer}
+		for j, expr := range exprs {
+			if f, ok := ev.computeField(opts.Expressions[j].Name, expr); ok {
+				r.Expressions = append(r.Expressions, f)
+			}
+		}
+		res.Results = append(res.Results, r)
+	}
+	return res, nil
+}
@@ -0,0 +1,233 @@
+package searchindex
+
+import (
+	"testing"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIndex(t *testing.T) *Index {
+	index := NewIndex()
+	docs := map[string]*Document{
+		"1": {Rank: 3, Fields: []Field{
+			{Name: "title", Value: "Harry Potter and the Philosopher's Stone"},
+			{Name: "type", Value: Atom("Novel")},
+			{Name: "pages", Value: float64(223)},
+			{Name: "published", Value: time.Date(1997, 6, 26, 10, 0, 0, 0, time.UTC)},
+		}},
+		"2": {Rank: 2, Fields: []Field{
+			{Name: "title", Value: "The Hobbit"},
+			{Name: "body", Value: HTML("<p>In a hole in the ground <b>lived</b> a hobbit.</p>")},
+			{Name: "type", Value: Atom("novel")},
+			{Name: "type", Value: Atom("fantasy")},
+			{Name: "pages", Value: float64(310)},
+			{Name: "published", Value: time.Date(1937, 9, 21, 0, 0, 0, 0, time.UTC)},
+		}},
+		"3": {Rank: 2, Fields: []Field{
+			{Name: "title", Value: "Field of the Potter"},
+			{Name: "type", Value: Atom("mystery novel")},
+			{Name: "pages", Value: 150.5},
+		}},
+	}
+	for id, doc := range docs {
+		_, err := index.Put(id, doc)
+		require.NoError(t, err)
+	}
+	return index
+}
+
+func TestIndex_Search(t *testing.T) {
+	index := newTestIndex(t)
+	cases := []struct {
+		Query    string
+		Expected []string
+	}{
+		{``, []string{"1", "2", "3"}},
+		{`potter`, []string{"1", "3"}},
+		{`POTTER harry`, []string{"1"}},
+		{`"harry potter"`, []string{"1"}},
+		{`"potter harry"`, nil},
+		{`title:hobbit`, []string{"2"}},
+		{`body:lived`, []string{"2"}},
+		{`body:b`, nil},
+		{`type:novel`, []string{"1", "2"}},
+		{`type = "mystery novel"`, []string{"3"}},
+		{`type:mystery`, nil},
+		{`type:fantasy`, []string{"2"}},
+		{`type != novel`, []string{"3"}},
+		{`NOT potter`, []string{"2"}},
+		{`pages < 223`, []string{"3"}},
+		{`pages <= 223`, []string{"1", "3"}},
+		{`pages:310 OR pages = 150.5`, []string{"2", "3"}},
+		{`223`, []string{"1"}},
+		{`published = 1997-06-26`, []string{"1"}},
+		{`published >= 1937-09-21 published < 1997-06-26`, []string{"2"}},
+		{`published < 1997-06-26T10:00:01Z`, []string{"1", "2"}},
+		{`title < potter`, nil},
+		{`unknown:potter`, nil},
+	}
+	for _, c := range cases {
+		res, err := index.Search(c.Query, &SearchOptions{IDsOnly: true})
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		var ids []string
+		for _, r := range res.Results {
+			ids = append(ids, r.ID)
+			assert.Nil(t, r.Document)
+		}
+		assert.Equal(t, c.Expected, ids, c.Query)
+		assert.Equal(t, len(c.Expected), res.Count, c.Query)
+	}
+
+	t.Run("options", func(t *testing.T) {
+		res, err := index.Search(`novel`, &SearchOptions{Limit: 1, Offset: 1})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2, res.Count)
+			if assert.Len(t, res.Results, 1) {
+				assert.Equal(t, "2", res.Results[0].ID)
+				assert.Equal(t, "The Hobbit", res.Results[0].Document.Fields[0].Value)
+			}
+		}
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := index.Search(`(potter`, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		_, err := index.Search(`novel`, &SearchOptions{Offset: -1})
+		assert.EqualError(t, err, "searchquery/searchindex: offset must not be negative, got -1")
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		index := NewIndex(searchquery.WithTimeZone(time.FixedZone("JST", 9*60*60)))
+		_, err := index.Put("1", &Document{Fields: []Field{
+			{Name: "published", Value: time.Date(1997, 6, 26, 20, 0, 0, 0, time.UTC)},
+		}})
+		require.NoError(t, err)
+		for s, n := range map[string]int{
+			`published = 1997-06-27`: 1,
+			`published = 1997-06-26`: 0,
+			`published < 1997-06-27`: 0,
+			`published > 1997-06-26`: 1,
+		} {
+			res, err := index.Search(s, nil)
+			if assert.NoError(t, err, s) {
+				assert.Equal(t, n, res.Count, s)
+			}
+		}
+	})
+
+	t.Run("midnight", func(t *testing.T) {
+		index := NewIndex()
+		_, err := index.Put("1", &Document{Fields: []Field{
+			{Name: "d", Value: time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC)},
+		}})
+		require.NoError(t, err)
+		for s, n := range map[string]int{
+			`d > 2020-01-01T00:00:00Z`:                    1,
+			`d = 2020-01-01T00:00:00Z`:                    0,
+			`d > 2020-01-01`:                              0,
+			`d = 2020-01-01`:                              1,
+			`d = 2020-01-01 AND d > 2020-01-01T00:00:00Z`: 1,
+			`d > 2020-01-01T00:00:00Z AND d > 2020-01-01`: 0,
+		} {
+			res, err := index.Search(s, nil)
+			if assert.NoError(t, err, s) {
+				assert.Equal(t, n, res.Count, s)
+			}
+		}
+	})
+}
+
+func TestIndex_SearchAnalysis(t *testing.T) {
+	index := NewIndex()
+	for id, doc := range map[string]*Document{
+		"1": {Rank: 2, Fields: []Field{{Name: "title", Value: "Running with the Dogs"}}},
+		"2": {Rank: 1, Fields: []Field{{Name: "title", Value: "Café Society"}}},
+		"3": {Rank: 3, Fields: []Field{{Name: "title", Value: "東京都の地図"}}},
+	} {
+		_, err := index.Put(id, doc)
+		require.NoError(t, err)
+	}
+	cases := []struct {
+		Query    string
+		Expected []string
+	}{
+		{`dog`, nil},
+		{`~dog`, []string{"1"}},
+		{`title:~runs`, []string{"1"}},
+		{`~"run with"`, []string{"1"}},
+		{`~"runs dogs"`, nil},
+		{`cafe`, []string{"2"}},
+		{`"CAFÉ"`, []string{"2"}},
+		{`"東京"`, []string{"3"}},
+		{`"京都"`, []string{"3"}},
+		{`"地図"`, []string{"3"}},
+		{`"東京都"`, []string{"3"}},
+		{`"大阪"`, nil},
+	}
+	for _, c := range cases {
+		res, err := index.Search(c.Query, &SearchOptions{IDsOnly: true})
+		if assert.NoError(t, err, c.Query) {
+			assert.Equal(t, c.Expected, resultIDs(res), c.Query)
+		}
+	}
+
+	t.Run("analyzer", func(t *testing.T) {
+		index := NewIndexWithAnalyzer(analysis.New(analysis.WordTokenizer, analysis.LowerCaseFilter, analysis.PorterStemFilter))
+		_, err := index.Put("1", &Document{Fields: []Field{{Name: "title", Value: "Running with the Dogs"}}})
+		require.NoError(t, err)
+		res, err := index.Search(`dog`, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, res.Count)
+		}
+	})
+}
+
+func TestIndex_PutGetDelete(t *testing.T) {
+	index := NewIndex()
+	id, err := index.Put("", &Document{Fields: []Field{{Name: "title", Value: "x"}}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	doc, err := index.Get(id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Field{{Name: "title", Value: "x"}}, doc.Fields)
+		assert.NotZero(t, doc.Rank)
+	}
+
+	_, err = index.Put(id, &Document{Fields: []Field{{Name: "title", Value: "y"}}})
+	require.NoError(t, err)
+	res, err := index.Search("x OR y", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, res.Count)
+		assert.Equal(t, "y", res.Results[0].Document.Fields[0].Value)
+	}
+
+	assert.NoError(t, index.Delete(id))
+	_, err = index.Get(id)
+	assert.Equal(t, ErrNoSuchDocument, err)
+	assert.NoError(t, index.Delete(id))
+
+	for _, c := range []struct {
+		ID  string
+		Doc *Document
+	}{
+		{"!x", &Document{}},
+		{"a b", &Document{}},
+		{"a", nil},
+		{"a", &Document{Fields: []Field{{Name: "1a", Value: "x"}}}},
+		{"a", &Document{Fields: []Field{{Name: "a.b", Value: "x"}}}},
+		{"a", &Document{Fields: []Field{{Name: "a", Value: 1}}}},
+	} {
+		_, err := index.Put(c.ID, c.Doc)
+		assert.Error(t, err, "%q %#v", c.ID, c.Doc)
+	}
+}
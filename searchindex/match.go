@@ -0,0 +1,285 @@
+package searchindex
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindAtom
+	kindNumber
+	kindDate
+	kindGeo
+)
+
+// field is an analyzed field, tokens and their stems are of text and HTML fields.
+type field struct {
+	name string
+
+	kind fieldKind
+
+	tokens []string
+
+	stems []string
+
+	atom string
+
+	number float64
+
+	date time.Time
+}
+
+var htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+func analyze(f *Field, analyzer analysis.Analyzer) field {
+	a := field{name: f.Name}
+	switch v := f.Value.(type) {
+	case string:
+		a.kind = kindText
+		a.tokens = analysis.Terms(analyzer.Analyze(v))
+		a.stems = stems(a.tokens)
+	case HTML:
+		a.kind = kindText
+		a.tokens = analysis.Terms(analyzer.Analyze(htmlTagRegexp.ReplaceAllString(string(v), " ")))
+		a.stems = stems(a.tokens)
+	case Atom:
+		a.kind = kindAtom
+		a.atom = string(v)
+	case float64:
+		a.kind = kindNumber
+		a.number = v
+	case time.Time:
+		a.kind = kindDate
+		a.date = v
+	case GeoPoint:
+		a.kind = kindGeo
+	}
+	return a
+}
+
+func stems(words []string) []string {
+	v := make([]string, len(words))
+	for i, w := range words {
+		v[i] = analysis.Stem(w)
+	}
+	return v
+}
+
+// operand is a value of a query, words are of a string value analyzed as text fields, which are stemmed
+// for ~word.
+type operand struct {
+	value ast.Value
+
+	words []string
+
+	stem bool
+
+	// date is set for a date without time of day, which compares with the day of fields.
+	date bool
+}
+
+func newOperand(v ast.Value, stem, date bool, analyzer analysis.Analyzer) *operand {
+	o := &operand{value: v, stem: stem, date: date}
+	if s, err := ast.AsString(v); err == nil {
+		o.words = analysis.Terms(analyzer.Analyze(s))
+		if stem {
+			o.words = stems(o.words)
+		}
+	}
+	return o
+}
+
+// matcher reports whether a document of fields matches.
+type matcher func(fields []field) bool
+
+func compile(expr ast.Expr, dates map[ast.Expr]bool, analyzer analysis.Analyzer) matcher {
+	return compileExpr(expr, "", dates, analyzer)
+}
+
+// dateExprs returns the expressions of expr, parsed from query, which values are dates without time of day
+// in query, e.g. "published = 2020-01-02". Time values of expr are in the order of time tokens of query.
+func dateExprs(query string, expr ast.Expr) map[ast.Expr]bool {
+	var dates []bool
+	for _, t := range searchquery.Tokenize(query, searchquery.WithMaxInputBytes(len(query))) {
+		if t.Kind == searchquery.TokenTime {
+			dates = append(dates, !strings.Contains(t.Text, "T"))
+		}
+	}
+	exprs := map[ast.Expr]bool{}
+	var walk func(ast.Expr)
+	walk = func(expr ast.Expr) {
+		var v ast.Value
+		switch e := expr.(type) {
+		case ast.And:
+			for _, e := range e {
+				walk(e)
+			}
+		case ast.Or:
+			for _, e := range e {
+				walk(e)
+			}
+		case *ast.Not:
+			walk(e.Expr)
+		case *ast.ColonExpr:
+			walk(e.Expr)
+		case *ast.OperatorExpr:
+			v = e.Value
+		case *ast.KeywordExpr:
+			v = e.Value
+		}
+		if _, ok := v.(ast.TimeValue); ok && len(dates) > 0 {
+			if dates[0] {
+				exprs[expr] = true
+			}
+			dates = dates[1:]
+		}
+	}
+	walk(expr)
+	return exprs
+}
+
+// compileExpr compiles expr, name is non-empty inside of property:expr.
+func compileExpr(expr ast.Expr, name string, dates map[ast.Expr]bool, analyzer analysis.Analyzer) matcher {
+	switch e := expr.(type) {
+	case ast.And:
+		ms := make([]matcher, len(e))
+		for i, v := range e {
+			ms[i] = compileExpr(v, name, dates, analyzer)
+		}
+		return func(fields []field) bool {
+			for _, m := range ms {
+				if !m(fields) {
+					return false
+				}
+			}
+			return true
+		}
+	case ast.Or:
+		ms := make([]matcher, len(e))
+		for i, v := range e {
+			ms[i] = compileExpr(v, name, dates, analyzer)
+		}
+		return func(fields []field) bool {
+			for _, m := range ms {
+				if m(fields) {
+					return true
+				}
+			}
+			return false
+		}
+	case *ast.Not:
+		m := compileExpr(e.Expr, name, dates, analyzer)
+		return func(fields []field) bool {
+			return !m(fields)
+		}
+	case *ast.OperatorExpr:
+		if e.Operator == ast.OpNeq {
+			m := fieldMatcher(e.Property, ast.OpEq, newOperand(e.Value, false, dates[e], analyzer))
+			return func(fields []field) bool {
+				return !m(fields)
+			}
+		}
+		return fieldMatcher(e.Property, e.Operator, newOperand(e.Value, false, dates[e], analyzer))
+	case *ast.ColonExpr:
+		return compileExpr(e.Expr, e.Property, dates, analyzer)
+	case *ast.KeywordExpr:
+		// keywords outside of property:expr search over all fields
+		return fieldMatcher(name, ast.OpEq, newOperand(e.Value, e.Stem, dates[e], analyzer))
+	default:
+		return func([]field) bool { return false }
+	}
+}
+
+// fieldMatcher matches documents which have a field of name satisfying op v, any field if name is empty.
+func fieldMatcher(name string, op ast.Op, v *operand) matcher {
+	return func(fields []field) bool {
+		for i := range fields {
+			if name != "" && fields[i].name != name {
+				continue
+			}
+			if fields[i].match(op, v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// match reports whether f op o holds, op is not OpNeq.
+// Text fields match words or phrases, and do not support ordered comparisons.
+func (f *field) match(op ast.Op, o *operand) bool {
+	v := o.value
+	switch f.kind {
+	case kindText:
+		if op != ast.OpEq {
+			return false
+		}
+		if o.stem {
+			return containsPhrase(f.stems, o.words)
+		}
+		return containsPhrase(f.tokens, o.words)
+	case kindAtom:
+		s, err := ast.AsString(v)
+		if op != ast.OpEq || err != nil {
+			return false
+		}
+		return strings.EqualFold(f.atom, s)
+	case kindNumber:
+		n, err := ast.AsFloat64(v)
+		if err != nil {
+			return false
+		}
+		ok, _ := op.Compare(ast.FloatValue(f.number), ast.FloatValue(n))
+		return ok
+	case kindDate:
+		t, err := ast.AsTime(v)
+		if err != nil {
+			return false
+		}
+		d := f.date
+		// dates without time of day compare with the day of the field, in the time zone of the query
+		if o.date {
+			loc := t.Location()
+			y, m, day := d.In(loc).Date()
+			d = time.Date(y, m, day, 0, 0, 0, 0, loc)
+		}
+		ok, _ := op.Compare(ast.TimeValue(d), ast.TimeValue(t))
+		return ok
+	default:
+		return false
+	}
+}
+
+// containsPhrase reports whether phrase appears in tokens as a sequence.
+func containsPhrase(tokens, phrase []string) bool {
+	return countPhrase(tokens, phrase) > 0
+}
+
+// countPhrase returns the number of occurrences of phrase in tokens.
+func countPhrase(tokens, phrase []string) int {
+	if len(phrase) == 0 {
+		return 0
+	}
+	n := 0
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		ok := true
+		for j := range phrase {
+			if tokens[i+j] != phrase[j] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			n++
+		}
+	}
+	return n
+}
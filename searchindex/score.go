@@ -0,0 +1,209 @@
+package searchindex
+
+import (
+	"math"
+	"strings"
+
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// ScoringOptions enables relevance scoring of results by BM25 over the words of text and atom fields, which
+// approximates the match scorer of GAE.
+type ScoringOptions struct {
+	// Boosts multiplies scores of matches in fields by name, 1 for missing names.
+	Boosts map[string]float64
+
+	// K1 is the term frequency saturation of BM25, 1.2 by default.
+	K1 float64
+
+	// B is the length normalization of BM25 between 0 and 1, 0.75 if nil. Zero disables the normalization.
+	B *float64
+}
+
+// term is a word or phrase of a query, in a field of name or any field if name is empty.
+type term struct {
+	name string
+
+	raw string
+
+	// stem matches stems of words for ~word.
+	stem bool
+
+	phrase []string
+}
+
+// collectScoreTerms appends terms of keywords and equalities in expr to terms, except negated ones which
+// never contribute to scores.
+func collectScoreTerms(expr ast.Expr, name string, terms []term) []term {
+	switch x := expr.(type) {
+	case ast.And:
+		for _, v := range x {
+			terms = collectScoreTerms(v, name, terms)
+		}
+	case ast.Or:
+		for _, v := range x {
+			terms = collectScoreTerms(v, name, terms)
+		}
+	case *ast.ColonExpr:
+		terms = collectScoreTerms(x.Expr, x.Property, terms)
+	case *ast.OperatorExpr:
+		if x.Operator == ast.OpEq {
+			terms = collectScoreTerms(&ast.KeywordExpr{Value: x.Value}, x.Property, terms)
+		}
+	case *ast.KeywordExpr:
+		if s, err := ast.AsString(x.Value); err == nil {
+			terms = append(terms, term{name: name, raw: s, stem: x.Stem})
+		}
+	}
+	return terms
+}
+
+// scorer scores documents against terms with statistics over all documents of an index.
+type scorer struct {
+	opts ScoringOptions
+
+	// b is ScoringOptions.B or its default.
+	b float64
+
+	terms []term
+
+	entries []*entry
+
+	// avgLen is the average length of fields by name, of documents having the field.
+	avgLen map[string]float64
+
+	// df is the number of documents having a term in a field.
+	df map[dfKey]int
+}
+
+type dfKey struct {
+	name, raw string
+
+	stem bool
+}
+
+func newScorer(opts *ScoringOptions, expr ast.Expr, entries []*entry, analyzer analysis.Analyzer) *scorer {
+	s := &scorer{
+		opts:    *opts,
+		entries: entries,
+		avgLen:  map[string]float64{},
+		df:      map[dfKey]int{},
+	}
+	if s.opts.K1 <= 0 {
+		s.opts.K1 = 1.2
+	}
+	s.b = 0.75
+	if s.opts.B != nil {
+		s.b = *s.opts.B
+	}
+	if expr != nil {
+		s.terms = collectScoreTerms(expr, "", nil)
+	}
+	for i := range s.terms {
+		t := &s.terms[i]
+		t.phrase = analysis.Terms(analyzer.Analyze(t.raw))
+		if t.stem {
+			t.phrase = stems(t.phrase)
+		}
+	}
+
+	total := map[string]int{}
+	count := map[string]int{}
+	for _, e := range entries {
+		seen := map[string]bool{}
+		for i := range e.fields {
+			f := &e.fields[i]
+			switch f.kind {
+			case kindText:
+				total[f.name] += len(f.tokens)
+			case kindAtom:
+				total[f.name]++
+			default:
+				continue
+			}
+			if !seen[f.name] {
+				seen[f.name] = true
+				count[f.name]++
+			}
+		}
+	}
+	for name, n := range count {
+		s.avgLen[name] = float64(total[name]) / float64(n)
+	}
+	return s
+}
+
+// score returns the sum of BM25 scores of the terms in e.
+func (s *scorer) score(e *entry) float64 {
+	var score float64
+	for _, t := range s.terms {
+		var names []string
+		if t.name != "" {
+			names = []string{t.name}
+		} else {
+			seen := map[string]bool{}
+			for i := range e.fields {
+				if !seen[e.fields[i].name] {
+					seen[e.fields[i].name] = true
+					names = append(names, e.fields[i].name)
+				}
+			}
+		}
+		for _, name := range names {
+			tf, length := termFrequency(e, name, &t)
+			if tf == 0 {
+				continue
+			}
+			boost := 1.0
+			if v, ok := s.opts.Boosts[name]; ok {
+				boost = v
+			}
+			k1, b := s.opts.K1, s.b
+			norm := 1 - b + b*length/s.avgLen[name]
+			score += boost * s.idf(name, &t) * tf * (k1 + 1) / (tf + k1*norm)
+		}
+	}
+	return score
+}
+
+func (s *scorer) idf(name string, t *term) float64 {
+	key := dfKey{name, t.raw, t.stem}
+	df, ok := s.df[key]
+	if !ok {
+		for _, e := range s.entries {
+			if tf, _ := termFrequency(e, name, t); tf > 0 {
+				df++
+			}
+		}
+		s.df[key] = df
+	}
+	n := float64(len(s.entries))
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+// termFrequency returns the occurrences of t in fields of name in e, and the length of the fields in words.
+// An atom field is a word which occurs if it equals to t as a whole.
+func termFrequency(e *entry, name string, t *term) (tf, length float64) {
+	for i := range e.fields {
+		f := &e.fields[i]
+		if f.name != name {
+			continue
+		}
+		switch f.kind {
+		case kindText:
+			if t.stem {
+				tf += float64(countPhrase(f.stems, t.phrase))
+			} else {
+				tf += float64(countPhrase(f.tokens, t.phrase))
+			}
+			length += float64(len(f.tokens))
+		case kindAtom:
+			if strings.EqualFold(f.atom, t.raw) {
+				tf++
+			}
+			length++
+		}
+	}
+	return tf, length
+}
@@ -0,0 +1,97 @@
+package searchindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchScoring(t *testing.T) {
+	index := newTestIndex(t)
+
+	res, err := index.Search(`potter`, &SearchOptions{IDsOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, resultIDs(res))
+
+	// the shorter title is more relevant regardless of rank
+	res, err = index.Search(`potter`, &SearchOptions{
+		IDsOnly:     true,
+		Scoring:     &ScoringOptions{},
+		Expressions: []FieldExpression{{Name: "score", Expr: "_score"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "1"}, resultIDs(res))
+	assert.Greater(t, res.Results[0].Score, res.Results[1].Score)
+	assert.Greater(t, res.Results[1].Score, 0.0)
+	assert.Equal(t, []Field{{Name: "score", Value: res.Results[0].Score}}, res.Results[0].Expressions)
+
+	// words of rare fields are more relevant, negated words do not count
+	res, err = index.Search(`potter OR fantasy OR NOT harry`, &SearchOptions{IDsOnly: true, Scoring: &ScoringOptions{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "3", "1"}, resultIDs(res))
+
+	// documents without matched words keep the order of rank
+	res, err = index.Search(``, &SearchOptions{IDsOnly: true, Scoring: &ScoringOptions{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, resultIDs(res))
+	for _, r := range res.Results {
+		assert.Equal(t, 0.0, r.Score)
+	}
+}
+
+func TestIndex_SearchScoringB(t *testing.T) {
+	index := newTestIndex(t)
+	b := func(v float64) *float64 { return &v }
+
+	// without the length normalization, scores of a word in both titles tie and the rank orders them
+	res, err := index.Search(`potter`, &SearchOptions{IDsOnly: true, Scoring: &ScoringOptions{B: b(0)}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, resultIDs(res))
+	assert.Equal(t, res.Results[0].Score, res.Results[1].Score)
+
+	res, err = index.Search(`potter`, &SearchOptions{IDsOnly: true, Scoring: &ScoringOptions{B: b(1)}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "1"}, resultIDs(res))
+
+	for _, v := range []float64{-0.1, 1.5} {
+		_, err := index.Search(`potter`, &SearchOptions{Scoring: &ScoringOptions{B: b(v)}})
+		assert.Error(t, err, "%v", v)
+	}
+}
+
+func TestIndex_SearchScoringBoosts(t *testing.T) {
+	index := NewIndex()
+	for id, doc := range map[string]*Document{
+		"a": {Rank: 1, Fields: []Field{{Name: "title", Value: "gopher"}, {Name: "body", Value: "a small animal"}}},
+		"b": {Rank: 1, Fields: []Field{{Name: "title", Value: "animal"}, {Name: "body", Value: "a small gopher"}}},
+		"c": {Rank: 1, Fields: []Field{{Name: "title", Value: "bird"}, {Name: "body", Value: "a small bird"}}},
+	} {
+		_, err := index.Put(id, doc)
+		require.NoError(t, err)
+	}
+	cases := []struct {
+		Query    string
+		Boosts   map[string]float64
+		Expected []string
+	}{
+		{`gopher`, map[string]float64{"title": 2}, []string{"a", "b"}},
+		{`gopher`, map[string]float64{"body": 2}, []string{"b", "a"}},
+		{`title:gopher OR body:gopher`, map[string]float64{"body": 2}, []string{"b", "a"}},
+		{`gopher OR bird`, nil, []string{"c", "a", "b"}},
+	}
+	for _, c := range cases {
+		res, err := index.Search(c.Query, &SearchOptions{IDsOnly: true, Scoring: &ScoringOptions{Boosts: c.Boosts}})
+		if assert.NoError(t, err, c.Query) {
+			assert.Equal(t, c.Expected, resultIDs(res), c.Query)
+		}
+	}
+}
+
+func resultIDs(res *SearchResult) []string {
+	var ids []string
+	for _, r := range res.Results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
@@ -0,0 +1,204 @@
+package searchindex
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+// defaultSnippetChars is the length of snippets without the max chars argument.
+const defaultSnippetChars = 160
+
+// snippet computes snippet(query, field[, maxChars]), an HTML excerpt of field around the first word of query
+// with matched words in <b>.
+func (e *evaluator) snippet(x *ast.Call) (ast.Value, error) {
+	if len(x.Args) < 2 || len(x.Args) > 3 {
+		return nil, fmt.Errorf("%s: wrong number of arguments to %s", pkgName, x.Func)
+	}
+	qv, err := e.eval(x.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	q, ok := qv.(ast.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("%s: query of snippet must be a string", pkgName)
+	}
+	name, err := fieldArg(x, 1)
+	if err != nil {
+		return nil, err
+	}
+	max := defaultSnippetChars
+	if len(x.Args) == 3 {
+		v, err := e.eval(x.Args[2])
+		if err != nil {
+			return nil, err
+		}
+		n, ok := v.(ast.IntegerValue)
+		if !ok || n <= 0 {
+			return nil, fmt.Errorf("%s: max chars of snippet must be a positive integer", pkgName)
+		}
+		max = int(n)
+	}
+	expr, err := searchquery.Parse(string(q))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		terms = map[string]bool{}
+		stems = map[string]bool{}
+	)
+	collectTerms(expr, e.analyzer, terms, stems)
+	match := func(word string) bool {
+		return terms[word] || stems[analysis.Stem(word)]
+	}
+
+	for _, f := range e.doc.Fields {
+		if f.Name != name {
+			continue
+		}
+		var text string
+		switch v := f.Value.(type) {
+		case string:
+			text = v
+		case HTML:
+			text = html.UnescapeString(htmlTagRegexp.ReplaceAllString(string(v), " "))
+		case Atom:
+			text = string(v)
+		default:
+			return nil, fmt.Errorf("%s: snippet of non-text field %q", pkgName, name)
+		}
+		return ast.StringValue(highlight(text, e.analyzer.Analyze(text), match, max)), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMissingField, name)
+}
+
+// collectTerms adds words of keywords in expr to terms, and stems of ~word to stems, except negated ones.
+func collectTerms(expr ast.Expr, analyzer analysis.Analyzer, terms, stems map[string]bool) {
+	switch x := expr.(type) {
+	case ast.And:
+		for _, v := range x {
+			collectTerms(v, analyzer, terms, stems)
+		}
+	case ast.Or:
+		for _, v := range x {
+			collectTerms(v, analyzer, terms, stems)
+		}
+	case *ast.ColonExpr:
+		collectTerms(x.Expr, analyzer, terms, stems)
+	case *ast.OperatorExpr:
+		if x.Operator == ast.OpEq {
+			collectTerms(&ast.KeywordExpr{Value: x.Value}, analyzer, terms, stems)
+		}
+	case *ast.KeywordExpr:
+		if s, err := ast.AsString(x.Value); err == nil {
+			for _, t := range analyzer.Analyze(s) {
+				if x.Stem {
+					stems[analysis.Stem(t.Term)] = true
+				} else {
+					terms[t.Term] = true
+				}
+			}
+		}
+	}
+}
+
+type span struct {
+	start, end int
+}
+
+// highlight cuts s into about max characters from a little before the first matched token, and wraps
+// matched tokens in <b>.
+func highlight(s string, tokens []analysis.Token, match func(term string) bool, max int) string {
+	rs := []rune(s)
+	// runeAt maps byte offsets of s to rune offsets
+	runeAt := make([]int, len(s)+1)
+	n := 0
+	for i := 0; i < len(s); n++ {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		for k := 0; k < size; k++ {
+			runeAt[i+k] = n
+		}
+		i += size
+	}
+	runeAt[len(s)] = n
+
+	var (
+		words   = make([]span, len(tokens))
+		matched = make([]bool, len(tokens))
+		first   = -1
+	)
+	for i, t := range tokens {
+		words[i] = span{runeAt[t.Start], runeAt[t.End]}
+		matched[i] = match(t.Term)
+		if first < 0 && matched[i] {
+			first = i
+		}
+	}
+
+	start := 0
+	if first > 0 {
+		// keep a few words of context before the match
+		start = words[first].start - max/4
+		if start <= 0 {
+			start = 0
+		} else {
+			for _, w := range words {
+				if w.start >= start {
+					start = w.start
+					break
+				}
+			}
+		}
+	}
+	end := start + max
+	if end >= len(rs) {
+		end = len(rs)
+	} else {
+		// cut at the end of the last whole word
+		cut := start
+		for _, w := range words {
+			if w.end <= end && w.start >= start && w.end > cut {
+				cut = w.end
+			}
+		}
+		if cut > start {
+			end = cut
+		}
+	}
+
+	// matched tokens may overlap, e.g. CJK bigrams
+	var marks []span
+	for i, w := range words {
+		if !matched[i] || w.start < start || w.end > end {
+			continue
+		}
+		if k := len(marks) - 1; k >= 0 && w.start <= marks[k].end {
+			if w.end > marks[k].end {
+				marks[k].end = w.end
+			}
+			continue
+		}
+		marks = append(marks, w)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	pos := start
+	for _, m := range marks {
+		b.WriteString(html.EscapeString(string(rs[pos:m.start])))
+		b.WriteString("<b>" + html.EscapeString(string(rs[m.start:m.end])) + "</b>")
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(rs[pos:end])))
+	if end < len(rs) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
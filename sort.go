@@ -0,0 +1,97 @@
+package searchquery
+
+import (
+	"fmt"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/kamichidu/go-gae-search-query/internal/scalarexpr"
+)
+
+// SortExpr is a sort key of search results.
+type SortExpr struct {
+	Expr ast.Scalar
+
+	Descending bool
+
+	// Default is the value of documents for which Expr can not be computed, e.g. which lack a field.
+	Default ast.Value
+}
+
+// ParseSortExpr parses a sort expression, e.g. "-date" or "price * 1.1 DEFAULT 0".
+// A leading "-" sorts in descending order, and a trailing DEFAULT gives the default value as a literal.
+func ParseSortExpr(s string) (*SortExpr, error) {
+	res, err := scalarexpr.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScalar(res.Expr, false); err != nil {
+		return nil, err
+	}
+	return &SortExpr{
+		Expr:       res.Expr,
+		Descending: res.Descending,
+		Default:    res.Default,
+	}, nil
+}
+
+type scalarFunc struct {
+	minArgs int
+
+	// maxArgs is -1 for variadic functions.
+	maxArgs int
+
+	// field is the index of the argument which must be a field, -1 if none.
+	field int
+
+	// returned is set for functions allowed in field expressions only.
+	returned bool
+}
+
+var scalarFuncs = map[string]scalarFunc{
+	"abs":      {minArgs: 1, maxArgs: 1, field: -1},
+	"count":    {minArgs: 1, maxArgs: 1, field: 0},
+	"distance": {minArgs: 2, maxArgs: 2, field: -1},
+	"geopoint": {minArgs: 2, maxArgs: 2, field: -1},
+	"log":      {minArgs: 1, maxArgs: 1, field: -1},
+	"max":      {minArgs: 1, maxArgs: -1, field: -1},
+	"min":      {minArgs: 1, maxArgs: -1, field: -1},
+	"pow":      {minArgs: 2, maxArgs: 2, field: -1},
+	"snippet":  {minArgs: 2, maxArgs: 3, field: 1, returned: true},
+}
+
+// checkScalar reports calls of unknown functions or with wrong arguments, returned allows functions of field
+// expressions.
+func checkScalar(expr ast.Scalar, returned bool) error {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if !e.Op.Valid() {
+			return fmt.Errorf("%s: invalid operator %v", pkgName, e.Op)
+		}
+		if err := checkScalar(e.X, returned); err != nil {
+			return err
+		}
+		return checkScalar(e.Y, returned)
+	case *ast.Neg:
+		return checkScalar(e.X, returned)
+	case *ast.Call:
+		f, ok := scalarFuncs[e.Func]
+		if !ok || f.returned && !returned {
+			return fmt.Errorf("%s: unknown function %s", pkgName, e.Func)
+		}
+		if len(e.Args) < f.minArgs || f.maxArgs >= 0 && len(e.Args) > f.maxArgs {
+			return fmt.Errorf("%s: wrong number of arguments to %s: %d", pkgName, e.Func, len(e.Args))
+		}
+		for i, arg := range e.Args {
+			if _, ok := arg.(*ast.FieldRef); i == f.field && !ok {
+				return fmt.Errorf("%s: argument %d of %s must be a field", pkgName, i+1, e.Func)
+			}
+			if err := checkScalar(arg, returned); err != nil {
+				return err
+			}
+		}
+	case *ast.Literal, *ast.FieldRef:
+	default:
+		return fmt.Errorf("%s: unknown scalar type %T", pkgName, expr)
+	}
+	return nil
+}
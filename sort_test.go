@@ -0,0 +1,122 @@
+package searchquery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSortExpr(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected *SortExpr
+	}{
+		{
+			`-date`,
+			&SortExpr{Expr: &ast.FieldRef{Name: "date"}, Descending: true},
+		},
+		{
+			`price * 1.1`,
+			&SortExpr{Expr: &ast.BinaryExpr{
+				Op: ast.ArithMul,
+				X:  &ast.FieldRef{Name: "price"},
+				Y:  &ast.Literal{Value: ast.FloatValue(1.1)},
+			}},
+		},
+		{
+			`a + b * -c - 2`,
+			&SortExpr{Expr: &ast.BinaryExpr{
+				Op: ast.ArithSub,
+				X: &ast.BinaryExpr{
+					Op: ast.ArithAdd,
+					X:  &ast.FieldRef{Name: "a"},
+					Y: &ast.BinaryExpr{
+						Op: ast.ArithMul,
+						X:  &ast.FieldRef{Name: "b"},
+						Y:  &ast.Neg{X: &ast.FieldRef{Name: "c"}},
+					},
+				},
+				Y: &ast.Literal{Value: ast.IntegerValue(2)},
+			}},
+		},
+		{
+			`+ (a + b) / 2 DEFAULT -1`,
+			&SortExpr{
+				Expr: &ast.BinaryExpr{
+					Op: ast.ArithDiv,
+					X: &ast.BinaryExpr{
+						Op: ast.ArithAdd,
+						X:  &ast.FieldRef{Name: "a"},
+						Y:  &ast.FieldRef{Name: "b"},
+					},
+					Y: &ast.Literal{Value: ast.IntegerValue(2)},
+				},
+				Default: ast.IntegerValue(-1),
+			},
+		},
+		{
+			`-max(_score, log(pages), 0) DEFAULT "zzz"`,
+			&SortExpr{
+				Expr: &ast.Call{Func: "max", Args: []ast.Scalar{
+					&ast.FieldRef{Name: "_score"},
+					&ast.Call{Func: "log", Args: []ast.Scalar{&ast.FieldRef{Name: "pages"}}},
+					&ast.Literal{Value: ast.IntegerValue(0)},
+				}},
+				Descending: true,
+				Default:    ast.StringValue("zzz"),
+			},
+		},
+		{
+			`distance(store, geopoint(35.5, -139)) DEFAULT 2011-01-02`,
+			&SortExpr{
+				Expr: &ast.Call{Func: "distance", Args: []ast.Scalar{
+					&ast.FieldRef{Name: "store"},
+					&ast.Call{Func: "geopoint", Args: []ast.Scalar{
+						&ast.Literal{Value: ast.FloatValue(35.5)},
+						&ast.Literal{Value: ast.IntegerValue(-139)},
+					}},
+				}},
+				Default: ast.TimeValue(mustParseTime("2011-01-02T00:00:00Z")),
+			},
+		},
+	}
+	for _, c := range cases {
+		expr, err := ParseSortExpr(c.Input)
+		if assert.NoError(t, err, c.Input) {
+			assert.Equal(t, c.Expected, expr, c.Input)
+		}
+	}
+
+	t.Run("json", func(t *testing.T) {
+		expr, err := ParseSortExpr(`count(tags) - 1`)
+		if assert.NoError(t, err) {
+			b, err := json.Marshal(expr.Expr)
+			if assert.NoError(t, err) {
+				assert.JSONEq(t, `{"-": [{"call": {"func": "count", "args": [{"field": "tags"}]}}, {"literal": {"I": 1}}]}`, string(b))
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, s := range []string{
+			``,
+			`a +`,
+			`(a`,
+			`a b`,
+			`a DEFAULT b`,
+			`a DEFAULT`,
+			`-a DEFAULT 1 2`,
+			`01`,
+			`unknown(a)`,
+			`log(a, b)`,
+			`max()`,
+			`count(1)`,
+			`snippet("a", body)`,
+		} {
+			_, err := ParseSortExpr(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
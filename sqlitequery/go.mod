@@ -0,0 +1,9 @@
+module github.com/kamichidu/go-gae-search-query/sqlitequery
+
+go 1.14
+
+require (
+	github.com/kamichidu/go-gae-search-query v0.0.0-20261019054831-ab1e8e5f1e68
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/stretchr/testify v1.6.1
+)
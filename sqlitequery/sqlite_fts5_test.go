@@ -0,0 +1,77 @@
+// +build sqlite_fts5
+
+package sqlitequery
+
+import (
+	"database/sql"
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// run with `go test -tags sqlite_fts5`, the driver builds FTS5 only with the tag.
+func TestTranslator_SQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	for _, stmt := range []string{
+		`CREATE TABLE books (id INTEGER PRIMARY KEY, genre TEXT, pages INTEGER, published TEXT)`,
+		`CREATE VIRTUAL TABLE books_fts USING fts5(title, body)`,
+		`INSERT INTO books VALUES (1, 'fantasy', 309, '1997-06-26'), (2, 'fantasy', 1178, '1954-07-29'), (3, 'horror', 447, '1977-01-28')`,
+		`INSERT INTO books_fts (rowid, title, body) VALUES
+			(1, 'Harry Potter and the Philosopher''s Stone', 'a young wizard goes to school'),
+			(2, 'The Lord of the Rings', 'a hobbit carries a ring to the fire'),
+			(3, 'The Shining', 'a hotel caretaker and his young son')`,
+	} {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+
+	tr := &Translator{
+		Table:       "books_fts",
+		RowID:       "books.id",
+		TextColumns: []string{"title", "body"},
+	}
+	cases := []struct {
+		Query    string
+		Expected []int64
+	}{
+		{`young`, []int64{1, 3}},
+		{`young NOT wizard`, []int64{3}},
+		{`title:"harry potter"`, []int64{1}},
+		{`title:(rings OR shining) pages < 1000`, []int64{3}},
+		{`genre:fantasy AND NOT body:hobbit`, []int64{1}},
+		{`hobbit OR genre:horror`, []int64{2, 3}},
+		{`title:NOT harry young`, []int64{3}},
+		{`"philosopher's" OR "a (ring"`, []int64{1, 2}},
+		{`pages >= 400 NOT title:(lord OR NOT shining)`, []int64{3}},
+		{`~carrying`, []int64{2}},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		where, args, err := tr.Translate(expr)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		rows, err := db.Query(`SELECT id FROM books WHERE `+where+` ORDER BY id`, args...)
+		if !assert.NoError(t, err, "%s: %s %v", c.Query, where, args) {
+			continue
+		}
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		rows.Close()
+		assert.Equal(t, c.Expected, ids, "%s: %s %v", c.Query, where, args)
+	}
+}
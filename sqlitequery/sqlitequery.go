@@ -0,0 +1,380 @@
+// Package sqlitequery translates query expressions into SQLite WHERE clauses using an FTS5 table.
+//
+// Keyword searches become an FTS5 query, matched through a subquery so that they can be freely combined
+// with ordinary comparisons:
+//
+//	rowid IN (SELECT rowid FROM docs_fts WHERE docs_fts MATCH ?)  -- with the argument `"title" : "harry potter"`
+package sqlitequery
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/kamichidu/go-gae-search-query/analysis"
+	"github.com/kamichidu/go-gae-search-query/ast"
+)
+
+const (
+	pkgName = "searchquery/sqlitequery"
+)
+
+type Translator struct {
+	// Table is the FTS5 table.
+	Table string
+
+	// RowID is the column of the queried table which joins rowid of Table, "rowid" by default.
+	RowID string
+
+	// TextColumns are the columns of Table. property:expr on them are searched by FTS5, and on other
+	// properties are compared by equality.
+	TextColumns []string
+
+	// KeywordColumns restricts keywords outside of property:expr to the columns, all columns of Table are
+	// searched if empty.
+	KeywordColumns []string
+
+	// Columns maps properties to SQL expressions. Properties not in Columns are used as quoted identifiers,
+	// e.g. users.name becomes "users"."name".
+	Columns map[string]string
+}
+
+// Translate returns a WHERE clause with ? placeholders and its arguments.
+func (t *Translator) Translate(expr ast.Expr) (string, []interface{}, error) {
+	if t.Table == "" {
+		return "", nil, fmt.Errorf("%s: no FTS5 table configured", pkgName)
+	}
+	tr := &translation{Translator: t}
+	var b strings.Builder
+	if err := tr.expr(&b, expr); err != nil {
+		return "", nil, err
+	}
+	return b.String(), tr.args, nil
+}
+
+type translation struct {
+	*Translator
+
+	args []interface{}
+}
+
+func (t *translation) expr(b *strings.Builder, expr ast.Expr) error {
+	if s, ok, err := t.ftsQuery(expr, false); err != nil {
+		return err
+	} else if ok {
+		t.match(b, s)
+		return nil
+	}
+	switch e := expr.(type) {
+	case ast.And:
+		return t.join(b, []ast.Expr(e), " AND ", t.expr)
+	case ast.Or:
+		return t.join(b, []ast.Expr(e), " OR ", t.expr)
+	case *ast.Not:
+		b.WriteString("NOT (")
+		if err := t.expr(b, e.Expr); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case *ast.OperatorExpr:
+		column, err := t.column(e.Property)
+		if err != nil {
+			return err
+		}
+		return t.compare(b, column, e.Operator, e.Value)
+	case *ast.ColonExpr:
+		if t.isTextColumn(e.Property) {
+			return t.text(b, e.Expr, e.Property)
+		}
+		column, err := t.column(e.Property)
+		if err != nil {
+			return err
+		}
+		return t.equality(b, e.Expr, column)
+	default:
+		return fmt.Errorf("%s: unknown expr type %T", pkgName, expr)
+	}
+	return nil
+}
+
+// text writes expr inside of property:expr on a column of the FTS5 table.
+func (t *translation) text(b *strings.Builder, expr ast.Expr, property string) error {
+	if s, ok, err := textQuery(expr, true); err != nil {
+		return err
+	} else if ok {
+		t.match(b, columnFilter([]string{property})+" : "+s)
+		return nil
+	}
+	switch e := expr.(type) {
+	case ast.And:
+		return t.join(b, []ast.Expr(e), " AND ", func(b *strings.Builder, v ast.Expr) error {
+			return t.text(b, v, property)
+		})
+	case ast.Or:
+		return t.join(b, []ast.Expr(e), " OR ", func(b *strings.Builder, v ast.Expr) error {
+			return t.text(b, v, property)
+		})
+	case *ast.Not:
+		b.WriteString("NOT (")
+		if err := t.text(b, e.Expr, property); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	default:
+		return t.expr(b, expr)
+	}
+	return nil
+}
+
+// equality writes expr inside of property:expr on a column out of the FTS5 table.
+func (t *translation) equality(b *strings.Builder, expr ast.Expr, column string) error {
+	switch e := expr.(type) {
+	case ast.And:
+		return t.join(b, []ast.Expr(e), " AND ", func(b *strings.Builder, v ast.Expr) error {
+			return t.equality(b, v, column)
+		})
+	case ast.Or:
+		return t.join(b, []ast.Expr(e), " OR ", func(b *strings.Builder, v ast.Expr) error {
+			return t.equality(b, v, column)
+		})
+	case *ast.Not:
+		b.WriteString("NOT (")
+		if err := t.equality(b, e.Expr, column); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case *ast.KeywordExpr:
+		if e.Stem {
+			return fmt.Errorf("%s: stemmed keyword ~%v on %s out of the FTS5 table", pkgName, e.Value, column)
+		}
+		return t.compare(b, column, ast.OpEq, e.Value)
+	default:
+		return t.expr(b, expr)
+	}
+	return nil
+}
+
+func (t *translation) join(b *strings.Builder, exprs []ast.Expr, sep string, fn func(*strings.Builder, ast.Expr) error) error {
+	if len(exprs) == 0 {
+		return fmt.Errorf("%s: empty%sexpression", pkgName, strings.ToLower(sep))
+	}
+	b.WriteString("(")
+	for i, v := range exprs {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		if err := fn(b, v); err != nil {
+			return err
+		}
+	}
+	b.WriteString(")")
+	return nil
+}
+
+func (t *translation) compare(b *strings.Builder, column string, op ast.Op, v ast.Value) error {
+	sqlOp, err := operator(op)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return fmt.Errorf("%s: nil value", pkgName)
+	}
+	b.WriteString(column + " " + sqlOp + " ?")
+	t.args = append(t.args, v.Raw())
+	return nil
+}
+
+func (t *translation) match(b *strings.Builder, query string) {
+	rowID := t.RowID
+	if rowID == "" {
+		rowID = "rowid"
+	}
+	table := quoteIdent(t.Table)
+	b.WriteString(rowID + " IN (SELECT rowid FROM " + table + " WHERE " + table + " MATCH ?)")
+	t.args = append(t.args, query)
+}
+
+// ftsQuery builds an FTS5 query from keywords and property:expr on text columns combined by AND, OR and NOT.
+// It reports false if expr contains anything else, or FTS5 can not express it, e.g. "a OR NOT b".
+func (t *translation) ftsQuery(expr ast.Expr, nested bool) (string, bool, error) {
+	switch e := expr.(type) {
+	case *ast.KeywordExpr:
+		s, err := keyword(e)
+		if err != nil {
+			return "", false, err
+		}
+		if len(t.KeywordColumns) > 0 {
+			s = columnFilter(t.KeywordColumns) + " : " + s
+		}
+		return s, true, nil
+	case *ast.ColonExpr:
+		if !t.isTextColumn(e.Property) {
+			return "", false, nil
+		}
+		s, ok, err := textQuery(e.Expr, true)
+		if err != nil || !ok {
+			return "", false, err
+		}
+		return columnFilter([]string{e.Property}) + " : " + s, true, nil
+	default:
+		return combine(expr, nested, t.ftsQuery)
+	}
+}
+
+// textQuery builds an FTS5 query for expr inside of property:expr.
+func textQuery(expr ast.Expr, nested bool) (string, bool, error) {
+	if e, ok := expr.(*ast.KeywordExpr); ok {
+		s, err := keyword(e)
+		if err != nil {
+			return "", false, err
+		}
+		return s, true, nil
+	}
+	return combine(expr, nested, textQuery)
+}
+
+func combine(expr ast.Expr, nested bool, fn func(ast.Expr, bool) (string, bool, error)) (string, bool, error) {
+	var s string
+	switch e := expr.(type) {
+	case ast.And:
+		var positives, negatives []string
+		for _, v := range e {
+			not, isNot := v.(*ast.Not)
+			if isNot {
+				v = not.Expr
+			}
+			part, ok, err := fn(v, true)
+			if err != nil || !ok {
+				return "", false, err
+			}
+			if isNot {
+				negatives = append(negatives, part)
+			} else {
+				positives = append(positives, part)
+			}
+		}
+		// NOT of FTS5 is a binary operator
+		if len(positives) == 0 {
+			return "", false, nil
+		}
+		s = strings.Join(positives, " AND ")
+		if len(negatives) > 0 {
+			if len(positives) > 1 {
+				s = "(" + s + ")"
+			}
+			s += " NOT " + strings.Join(negatives, " NOT ")
+		}
+		if len(positives)+len(negatives) == 1 {
+			return s, true, nil
+		}
+	case ast.Or:
+		if len(e) == 0 {
+			return "", false, nil
+		}
+		var parts []string
+		for _, v := range e {
+			if _, ok := v.(*ast.Not); ok {
+				return "", false, nil
+			}
+			part, ok, err := fn(v, true)
+			if err != nil || !ok {
+				return "", false, err
+			}
+			parts = append(parts, part)
+		}
+		s = strings.Join(parts, " OR ")
+		if len(parts) == 1 {
+			return s, true, nil
+		}
+	default:
+		return "", false, nil
+	}
+	if nested {
+		s = "(" + s + ")"
+	}
+	return s, true, nil
+}
+
+func (t *translation) isTextColumn(property string) bool {
+	for _, column := range t.TextColumns {
+		if column == property {
+			return true
+		}
+	}
+	return false
+}
+
+// keyword returns the FTS5 phrase of e. Stemmed keywords become a prefix query of the Porter stem of the word,
+// e.g. "run" * for ~running, which matches the words sharing the stem with a tokenizer such as porter.
+func keyword(e *ast.KeywordExpr) (string, error) {
+	if !e.Stem {
+		return phrase(e.Value)
+	}
+	s, err := ast.AsString(e.Value)
+	if err != nil {
+		return "", err
+	}
+	if s == "" || strings.IndexFunc(s, isNotWordRune) >= 0 {
+		return "", fmt.Errorf("%s: stemmed keyword ~%q is not a word", pkgName, s)
+	}
+	stem, err := phrase(ast.StringValue(analysis.Stem(strings.ToLower(s))))
+	if err != nil {
+		return "", err
+	}
+	return stem + " *", nil
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// phrase quotes a keyword as an FTS5 string, which makes FTS5 operators in it literal.
+func phrase(v ast.Value) (string, error) {
+	s, err := ast.AsString(v)
+	if err != nil {
+		return "", err
+	}
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`, nil
+}
+
+func columnFilter(columns []string) string {
+	if len(columns) == 1 {
+		return quoteIdent(columns[0])
+	}
+	var names []string
+	for _, column := range columns {
+		names = append(names, quoteIdent(column))
+	}
+	return "{" + strings.Join(names, " ") + "}"
+}
+
+func (t *translation) column(property string) (string, error) {
+	if column, ok := t.Columns[property]; ok {
+		return column, nil
+	}
+	if property == "" {
+		return "", fmt.Errorf("%s: empty property", pkgName)
+	}
+	var names []string
+	for _, name := range strings.Split(property, ".") {
+		names = append(names, quoteIdent(name))
+	}
+	return strings.Join(names, "."), nil
+}
+
+func operator(op ast.Op) (string, error) {
+	switch op {
+	case ast.OpEq:
+		return "=", nil
+	case ast.OpNeq:
+		return "<>", nil
+	case ast.OpLt, ast.OpLe, ast.OpGt, ast.OpGe:
+		return op.String(), nil
+	default:
+		return "", fmt.Errorf("%s: invalid operator %v", pkgName, op)
+	}
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
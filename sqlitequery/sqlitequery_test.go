@@ -0,0 +1,86 @@
+package sqlitequery
+
+import (
+	"testing"
+
+	searchquery "github.com/kamichidu/go-gae-search-query"
+	"github.com/kamichidu/go-gae-search-query/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslator_Translate(t *testing.T) {
+	tr := &Translator{
+		Table:       "books_fts",
+		TextColumns: []string{"title", "body"},
+	}
+	cases := []struct {
+		Query string
+		SQL   string
+		Args  []interface{}
+	}{
+		{
+			`blue guitar NOT fender`,
+			`rowid IN (SELECT rowid FROM "books_fts" WHERE "books_fts" MATCH ?)`,
+			[]interface{}{`("blue" AND "guitar") NOT "fender"`},
+		},
+		{
+			`title:"Harry Potter" AND pages < 500`,
+			`(rowid IN (SELECT rowid FROM "books_fts" WHERE "books_fts" MATCH ?) AND "pages" < ?)`,
+			[]interface{}{`"title" : "Harry Potter"`, int64(500)},
+		},
+		{
+			`title:(red OR "say \"hi\"") body:NOT x`,
+			`(rowid IN (SELECT rowid FROM "books_fts" WHERE "books_fts" MATCH ?) AND NOT (rowid IN (SELECT rowid FROM "books_fts" WHERE "books_fts" MATCH ?)))`,
+			[]interface{}{`"title" : ("red" OR "say ""hi""")`, `"body" : "x"`},
+		},
+		{
+			`red OR NOT white`,
+			`(rowid IN (SELECT rowid FROM "books_fts" WHERE "books_fts" MATCH ?) OR NOT (rowid IN (SELECT rowid FROM "books_fts" WHERE "books_fts" MATCH ?)))`,
+			[]interface{}{`"red"`, `"white"`},
+		},
+		{
+			`~running title:~Wizards`,
+			`rowid IN (SELECT rowid FROM "books_fts" WHERE "books_fts" MATCH ?)`,
+			[]interface{}{`"run" * AND "title" : "wizard" *`},
+		},
+		{
+			`genre:(fantasy OR NOT horror)`,
+			`("genre" = ? OR NOT ("genre" = ?))`,
+			[]interface{}{"fantasy", "horror"},
+		},
+	}
+	for _, c := range cases {
+		expr, err := searchquery.Parse(c.Query)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		sql, args, err := tr.Translate(expr)
+		if !assert.NoError(t, err, c.Query) {
+			continue
+		}
+		assert.Equal(t, c.SQL, sql, c.Query)
+		assert.Equal(t, c.Args, args, c.Query)
+	}
+
+	t.Run("keyword columns", func(t *testing.T) {
+		tr := &Translator{Table: "t", RowID: "docs.id", KeywordColumns: []string{"title", "body"}}
+		sql, args, err := tr.Translate(&ast.KeywordExpr{Value: ast.StringValue(`a"b`)})
+		if assert.NoError(t, err) {
+			assert.Equal(t, `docs.id IN (SELECT rowid FROM "t" WHERE "t" MATCH ?)`, sql)
+			assert.Equal(t, []interface{}{`{"title" "body"} : "a""b"`}, args)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, s := range []string{
+			`~"young wizard"`,
+			`genre:~fantasy`,
+		} {
+			expr, err := searchquery.Parse(s)
+			if assert.NoError(t, err, s) {
+				_, _, err = tr.Translate(expr)
+				assert.Error(t, err, s)
+			}
+		}
+	})
+}
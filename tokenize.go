@@ -0,0 +1,195 @@
+package searchquery
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// TokenKind classifies a Token.
+type TokenKind int
+
+const (
+	TokenInvalid TokenKind = iota
+	TokenProperty
+	TokenOperator
+	TokenColon
+	// TokenKeyword is AND, OR or NOT.
+	TokenKeyword
+	TokenString
+	TokenQuotedString
+	TokenInteger
+	TokenFloat
+	TokenTime
+	TokenBool
+	TokenParen
+	TokenComment
+	TokenWhitespace
+)
+
+func (v TokenKind) String() string {
+	switch v {
+	case TokenInvalid:
+		return "invalid"
+	case TokenProperty:
+		return "property"
+	case TokenOperator:
+		return "operator"
+	case TokenColon:
+		return "colon"
+	case TokenKeyword:
+		return "keyword"
+	case TokenString:
+		return "string"
+	case TokenQuotedString:
+		return "quoted-string"
+	case TokenInteger:
+		return "integer"
+	case TokenFloat:
+		return "float"
+	case TokenTime:
+		return "time"
+	case TokenBool:
+		return "bool"
+	case TokenParen:
+		return "paren"
+	case TokenComment:
+		return "comment"
+	case TokenWhitespace:
+		return "whitespace"
+	default:
+		return fmt.Sprintf("TokenKind(%d)", int(v))
+	}
+}
+
+// Token is a lexical element of a query.
+type Token struct {
+	Kind TokenKind
+
+	Text string
+
+	// Start and End are the byte offsets of Text in the query.
+	Start, End int
+}
+
+// tokenRules are the rules of the grammar which are tokens by themselves.
+var tokenRules = map[pegRule]TokenKind{
+	ruleProperty:     TokenProperty,
+	ruleOperator:     TokenOperator,
+	ruleColon:        TokenColon,
+	ruleAnd:          TokenKeyword,
+	ruleOr:           TokenKeyword,
+	ruleNot:          TokenKeyword,
+	ruleTime:         TokenTime,
+	ruleFloat:        TokenFloat,
+	ruleInteger:      TokenInteger,
+	ruleBool:         TokenBool,
+	ruleBareString:   TokenString,
+	ruleQuotedString: TokenQuotedString,
+	ruleComment:      TokenComment,
+	ruleSpace:        TokenWhitespace,
+}
+
+// Tokenize splits s into tokens, which cover all of s. Tokens of a valid query are classified as the parser
+// does. A query which does not parse is classified as far as the grammar accepts its beginning, the rest is
+// tokenized likewise after skipping what the grammar does not accept as TokenInvalid. Of opts, only
+// WithMaxInputBytes applies, which is 2000 by default, text beyond it is TokenInvalid.
+func Tokenize(s string, opts ...ParseOption) []Token {
+	max := inputLimit(opts)
+	if len(s) <= max {
+		return tokenize(s)
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return appendToken(tokenize(s[:max]), Token{Kind: TokenInvalid, Text: s[max:], Start: max, End: len(s)})
+}
+
+func tokenize(s string) []Token {
+	var q Query
+	q.Buffer = s
+	q.Init()
+	if err := q.Parse(); err != nil {
+		var tokens []Token
+		pos := 0
+		for _, seg := range parsePartial(s) {
+			if pos < seg.start {
+				tokens = appendToken(tokens, Token{Kind: TokenInvalid, Text: s[pos:seg.start], Start: pos, End: seg.start})
+			}
+			tokens = seg.appendTokens(tokens, s)
+			pos = seg.end
+		}
+		return tokens
+	}
+
+	seg := segment{end: len(s), tokens: q.tokens32.tree}
+	for i := range s {
+		seg.offsets = append(seg.offsets, i)
+	}
+	seg.offsets = append(seg.offsets, len(s))
+	return seg.appendTokens(nil, s)
+}
+
+// appendTokens appends tokens of the segment of the query s.
+func (seg *segment) appendTokens(all []Token, s string) []Token {
+	// a word at the end which is not followed by an operator yet is a keyword as far as the query goes
+	trailing := -1
+	if exprs := seg.at(seg.end, ruleExpr); len(exprs) > 0 && len(seg.at(seg.end, ruleExpectOperator)) > 0 {
+		trailing = int(exprs[0].begin)
+	}
+
+	var tokens []Token
+	for _, t := range seg.tokens {
+		kind, ok := tokenRules[t.pegRule]
+		if !ok || t.begin == t.end {
+			continue
+		}
+		start, end := seg.offsets[t.begin], seg.offsets[t.end]
+		switch {
+		case t.pegRule == ruleProperty && int(t.begin) == trailing && bareStringPattern.MatchString(s[start:end]):
+			kind = TokenString
+		case t.pegRule == ruleQuotedString && len(seg.at(end, ruleExpectQuote)) > 0:
+			// unterminated
+			kind = TokenInvalid
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: s[start:end], Start: start, End: end})
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Start < tokens[j].Start
+	})
+
+	// literals of the grammar are between tokens of rules
+	pos := seg.start
+	for _, t := range tokens {
+		all = appendLiterals(all, s, pos, t.Start)
+		all = appendToken(all, t)
+		pos = t.End
+	}
+	return appendLiterals(all, s, pos, seg.end)
+}
+
+// appendToken appends t, joining a run of whitespace or of invalid text into a token.
+func appendToken(tokens []Token, t Token) []Token {
+	n := len(tokens)
+	if n > 0 && (t.Kind == TokenWhitespace || t.Kind == TokenInvalid) && tokens[n-1].Kind == t.Kind && tokens[n-1].End == t.Start {
+		tokens[n-1].End = t.End
+		tokens[n-1].Text += t.Text
+		return tokens
+	}
+	return append(tokens, t)
+}
+
+// appendLiterals appends tokens of literals in s[start:end].
+func appendLiterals(tokens []Token, s string, start, end int) []Token {
+	for i := start; i < end; i++ {
+		kind := TokenInvalid
+		switch s[i] {
+		case '(', ')':
+			kind = TokenParen
+		case '~':
+			kind = TokenOperator
+		}
+		tokens = appendToken(tokens, Token{Kind: kind, Text: s[i : i+1], Start: i, End: i + 1})
+	}
+	return tokens
+}
@@ -0,0 +1,96 @@
+package searchquery
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenStrings(tokens []Token) []string {
+	var ss []string
+	for _, t := range tokens {
+		ss = append(ss, fmt.Sprintf("%v %q", t.Kind, t.Text))
+	}
+	return ss
+}
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected []string
+	}{
+		{`title:potter`, []string{`property "title"`, `colon ":"`, `string "potter"`}},
+		{`pages >= 10 AND NOT price < -1.5`, []string{
+			`property "pages"`, `whitespace " "`, `operator ">="`, `whitespace " "`, `integer "10"`,
+			`whitespace " "`, `keyword "AND"`, `whitespace " "`, `keyword "NOT"`, `whitespace " "`,
+			`property "price"`, `whitespace " "`, `operator "<"`, `whitespace " "`, `float "-1.5"`,
+		}},
+		{"(used = true OR at > 2020-01-02T03:04:05Z)  # 東京\r\n~\"東京 \\\"都\\\"\"", []string{
+			`paren "("`, `property "used"`, `whitespace " "`, `operator "="`, `whitespace " "`, `bool "true"`,
+			`whitespace " "`, `keyword "OR"`, `whitespace " "`, `property "at"`, `whitespace " "`, `operator ">"`,
+			`whitespace " "`, `time "2020-01-02T03:04:05Z"`, `paren ")"`, `whitespace "  "`, `comment "# 東京"`,
+			`whitespace "\r\n"`, `operator "~"`, `quoted-string "\"東京 \\\"都\\\"\""`,
+		}},
+		{`date:2020-01-02`, []string{`property "date"`, `colon ":"`, `time "2020-01-02"`}},
+		// invalid queries
+		{`title:(potter OR`, []string{`property "title"`, `colon ":"`, `paren "("`, `string "potter"`, `whitespace " "`, `keyword "OR"`}},
+		{`pages < 10 ! "open`, []string{
+			`property "pages"`, `whitespace " "`, `operator "<"`, `whitespace " "`, `integer "10"`, `whitespace " "`,
+			`invalid "!"`, `whitespace " "`, `invalid "\"open"`,
+		}},
+		{`a = = b`, []string{
+			`string "a"`, `whitespace " "`, `invalid "="`, `whitespace " "`, `invalid "="`, `whitespace " "`, `string "b"`,
+		}},
+		{`genre:(rock !jazz) ジャズ OR genre:r`, []string{
+			`string "genre"`, `invalid ":("`, `string "rock"`, `whitespace " "`, `invalid "!"`, `string "jazz"`,
+			`invalid ")"`, `whitespace " "`, `invalid "ジャズ"`, `whitespace " "`, `string "OR"`, `whitespace " "`,
+			`property "genre"`, `colon ":"`, `string "r"`,
+		}},
+		{"  東京 # x", []string{`whitespace "  "`, `invalid "東京"`, `whitespace " "`, `comment "# x"`}},
+		{``, nil},
+	}
+	for _, c := range cases {
+		tokens := Tokenize(c.Input)
+		assert.Equal(t, c.Expected, tokenStrings(tokens), c.Input)
+
+		var b strings.Builder
+		for i, tok := range tokens {
+			assert.Equal(t, c.Input[tok.Start:tok.End], tok.Text, c.Input)
+			if i > 0 {
+				assert.Equal(t, tokens[i-1].End, tok.Start, c.Input)
+			}
+			b.WriteString(tok.Text)
+		}
+		assert.Equal(t, c.Input, b.String())
+	}
+}
+
+func TestTokenize_Limit(t *testing.T) {
+	s := strings.Repeat("a é ", 4000)
+	start := time.Now()
+	tokens := Tokenize(s)
+	if assert.NotEmpty(t, tokens) {
+		last := tokens[len(tokens)-1]
+		assert.Equal(t, TokenInvalid, last.Kind)
+		assert.Equal(t, 2000, last.Start)
+		assert.Equal(t, len(s), last.End)
+	}
+	tokens = Tokenize(s, WithMaxInputBytes(len(s)))
+	assert.Equal(t, `whitespace " "`, tokenStrings(tokens)[len(tokens)-1])
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	// the limit is at a rune boundary
+	tokens = Tokenize("ab é", WithMaxInputBytes(4))
+	assert.Equal(t, []string{`string "ab"`, `whitespace " "`, `invalid "é"`}, tokenStrings(tokens))
+}
+
+func TestHighlight(t *testing.T) {
+	assert.Equal(t, "\x1b[36mtitle\x1b[0m\x1b[33m:\x1b[0mpotter \x1b[1;35mAND\x1b[0m \x1b[32m\"x\"\x1b[0m",
+		HighlightANSI(`title:potter AND "x"`))
+	assert.Equal(t, `<span class="q-property">a</span> <span class="q-operator">&lt;</span> `+
+		`<span class="q-quoted-string">&#34;&lt;b&gt;&#34;</span>`,
+		HighlightHTML(`a < "<b>"`, "q-"))
+}